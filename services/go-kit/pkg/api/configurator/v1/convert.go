@@ -0,0 +1,112 @@
+package configuratorv1
+
+import "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+
+// FromSelection converts the shared model into its wire form.
+func FromSelection(sel configurator.Selection) *Selection {
+	out := &Selection{
+		SchemaVersion:    int32(sel.SchemaVersion),
+		ConfigurationId:  sel.ConfigurationID,
+		Module:           sel.Module,
+		Layout:           sel.Layout,
+		Finish:           sel.Finish,
+		Currency:         sel.Currency,
+		Dimensions:       &Dimensions{LengthMm: int32(sel.Dimensions.LengthMM), HeightMm: int32(sel.Dimensions.HeightMM)},
+		Market:           sel.Market,
+		Locale:           sel.Locale,
+		Channel:          sel.Channel,
+		Acknowledgements: sel.Acknowledgements,
+	}
+	for _, opt := range sel.Options {
+		out.Options = append(out.Options, &SelectionOption{Id: opt.ID, Quantity: int32(opt.Quantity)})
+	}
+	if r := sel.Room; r != nil {
+		room := &Room{CeilingHeightMm: int32(r.CeilingHeightMM)}
+		for _, w := range r.Walls {
+			wall := &Wall{Id: w.ID, LengthMm: int32(w.LengthMM), XMm: int32(w.XMM), YMm: int32(w.YMM), HeadingDeg: int32(w.HeadingDeg)}
+			for _, o := range w.Openings {
+				wall.Openings = append(wall.Openings, &Opening{Kind: o.Kind, OffsetMm: int32(o.OffsetMM), WidthMm: int32(o.WidthMM)})
+			}
+			room.Walls = append(room.Walls, wall)
+		}
+		for _, p := range r.Placements {
+			room.Placements = append(room.Placements, &Placement{
+				Module:   p.Module,
+				WallId:   p.WallID,
+				Tier:     p.Tier,
+				OffsetMm: int32(p.OffsetMM),
+				WidthMm:  int32(p.WidthMM),
+				HeightMm: int32(p.HeightMM),
+				DepthMm:  int32(p.DepthMM),
+				Role:     p.Role,
+			})
+		}
+		if i := r.Island; i != nil {
+			island := &Island{LengthMm: int32(i.LengthMM), DepthMm: int32(i.DepthMM)}
+			for _, c := range i.Clearances {
+				island.Clearances = append(island.Clearances, &WallClearance{WallId: c.WallID, DistanceMm: int32(c.DistanceMM)})
+			}
+			room.Island = island
+		}
+		out.Room = room
+	}
+	return out
+}
+
+// ToSelection converts the wire form back into the shared model. A nil
+// message is the zero selection, which fails configurator validation.
+func ToSelection(x *Selection) configurator.Selection {
+	if x == nil {
+		return configurator.Selection{}
+	}
+	sel := configurator.Selection{
+		SchemaVersion:   int(x.GetSchemaVersion()),
+		ConfigurationID: x.GetConfigurationId(),
+		Module:          x.GetModule(),
+		Layout:          x.GetLayout(),
+		Finish:          x.GetFinish(),
+		Currency:        x.GetCurrency(),
+		Dimensions: configurator.Dimensions{
+			LengthMM: int(x.GetDimensions().GetLengthMm()),
+			HeightMM: int(x.GetDimensions().GetHeightMm()),
+		},
+		Market:           x.GetMarket(),
+		Locale:           x.GetLocale(),
+		Channel:          x.GetChannel(),
+		Acknowledgements: x.GetAcknowledgements(),
+	}
+	for _, opt := range x.GetOptions() {
+		sel.Options = append(sel.Options, configurator.SelectionOption{ID: opt.GetId(), Quantity: int(opt.GetQuantity())})
+	}
+	if r := x.GetRoom(); r != nil {
+		room := &configurator.Room{CeilingHeightMM: int(r.GetCeilingHeightMm())}
+		for _, w := range r.GetWalls() {
+			wall := configurator.Wall{ID: w.GetId(), LengthMM: int(w.GetLengthMm()), XMM: int(w.GetXMm()), YMM: int(w.GetYMm()), HeadingDeg: int(w.GetHeadingDeg())}
+			for _, o := range w.GetOpenings() {
+				wall.Openings = append(wall.Openings, configurator.Opening{Kind: o.GetKind(), OffsetMM: int(o.GetOffsetMm()), WidthMM: int(o.GetWidthMm())})
+			}
+			room.Walls = append(room.Walls, wall)
+		}
+		for _, p := range r.GetPlacements() {
+			room.Placements = append(room.Placements, configurator.Placement{
+				Module:   p.GetModule(),
+				WallID:   p.GetWallId(),
+				Tier:     p.GetTier(),
+				OffsetMM: int(p.GetOffsetMm()),
+				WidthMM:  int(p.GetWidthMm()),
+				HeightMM: int(p.GetHeightMm()),
+				DepthMM:  int(p.GetDepthMm()),
+				Role:     p.GetRole(),
+			})
+		}
+		if i := r.GetIsland(); i != nil {
+			island := &configurator.Island{LengthMM: int(i.GetLengthMm()), DepthMM: int(i.GetDepthMm())}
+			for _, c := range i.GetClearances() {
+				island.Clearances = append(island.Clearances, configurator.WallClearance{WallID: c.GetWallId(), DistanceMM: int(c.GetDistanceMm())})
+			}
+			room.Island = island
+		}
+		sel.Room = room
+	}
+	return sel
+}
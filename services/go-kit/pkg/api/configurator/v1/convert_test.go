@@ -0,0 +1,43 @@
+package configuratorv1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+func TestSelectionRoundTrip(t *testing.T) {
+	sel := configurator.Selection{
+		SchemaVersion:   1,
+		ConfigurationID: "cfg-1",
+		Module:          "base",
+		Layout:          "l-shape",
+		Finish:          "oak",
+		Currency:        "GBP",
+		Options:         []configurator.SelectionOption{{ID: "sink", Quantity: 1}, {ID: "drawer", Quantity: 4}},
+		Dimensions:      configurator.Dimensions{LengthMM: 3600, HeightMM: 900},
+		Room: &configurator.Room{
+			CeilingHeightMM: 2400,
+			Walls: []configurator.Wall{
+				{ID: "north", LengthMM: 3600, HeadingDeg: 90, Openings: []configurator.Opening{{Kind: configurator.OpeningWindow, OffsetMM: 600, WidthMM: 1200}}},
+			},
+			Placements: []configurator.Placement{{Module: "base", WallID: "north", Tier: "base", OffsetMM: 0, WidthMM: 600, Role: "sink"}},
+			Island:     &configurator.Island{LengthMM: 1800, DepthMM: 900, Clearances: []configurator.WallClearance{{WallID: "north", DistanceMM: 1100}}},
+		},
+		Market:           "gb",
+		Locale:           "en-GB",
+		Channel:          "dealer",
+		Acknowledgements: []string{"ack-1"},
+	}
+	got := ToSelection(FromSelection(sel))
+	if !reflect.DeepEqual(got, sel) {
+		t.Fatalf("round trip changed selection:\n got %+v\nwant %+v", got, sel)
+	}
+}
+
+func TestToSelectionNil(t *testing.T) {
+	if got := ToSelection(nil); !reflect.DeepEqual(got, configurator.Selection{}) {
+		t.Fatalf("expected zero selection, got %+v", got)
+	}
+}
@@ -0,0 +1,8 @@
+// Package api holds the generated protobuf messages and gRPC clients for the
+// services' RPC APIs, one package per proto package under
+// proto/kitchen at the module root. The *.pb.go files are generated with
+// protoc-gen-go v1.33.0 and protoc-gen-go-grpc v1.3.0; convert.go files are
+// hand-written and map the messages onto the shared models.
+package api
+
+//go:generate sh -c "protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/parvizcorp/kitchen-configurator/services/go-kit --go-grpc_out=../.. --go-grpc_opt=module=github.com/parvizcorp/kitchen-configurator/services/go-kit $(cd ../../proto && find kitchen -name '*.proto')"
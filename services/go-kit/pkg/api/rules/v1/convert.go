@@ -0,0 +1,100 @@
+package rulesv1
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+)
+
+// Params converts violation parameters into a Struct. Values go through
+// their JSON form, so anything the HTTP contract can carry survives; nil
+// and empty maps stay unset.
+func Params(params map[string]any) *structpb.Struct {
+	if len(params) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	out := &structpb.Struct{}
+	if err := out.UnmarshalJSON(raw); err != nil {
+		return nil
+	}
+	return out
+}
+
+// FromViolations converts rulesclient violations into their wire form.
+func FromViolations(vs []rulesclient.Violation) []*Violation {
+	out := make([]*Violation, 0, len(vs))
+	for _, v := range vs {
+		out = append(out, &Violation{
+			Rule:         v.Rule,
+			Code:         v.Code,
+			Severity:     v.Severity,
+			Message:      v.Message,
+			Paths:        v.Paths,
+			Options:      v.Options,
+			Params:       Params(v.Params),
+			Overridable:  v.Overridable,
+			AckToken:     v.AckToken,
+			Acknowledged: v.Acknowledged,
+		})
+	}
+	return out
+}
+
+// ToViolations converts wire violations into the rulesclient model.
+func ToViolations(vs []*Violation) []rulesclient.Violation {
+	out := make([]rulesclient.Violation, 0, len(vs))
+	for _, v := range vs {
+		var params map[string]any
+		if v.GetParams() != nil {
+			params = v.GetParams().AsMap()
+		}
+		out = append(out, rulesclient.Violation{
+			Rule:         v.GetRule(),
+			Code:         v.GetCode(),
+			Severity:     v.GetSeverity(),
+			Message:      v.GetMessage(),
+			Paths:        v.GetPaths(),
+			Options:      v.GetOptions(),
+			Params:       params,
+			Overridable:  v.GetOverridable(),
+			AckToken:     v.GetAckToken(),
+			Acknowledged: v.GetAcknowledged(),
+		})
+	}
+	return out
+}
+
+// ToResult converts a Validate response into the same verdict the HTTP
+// client returns, so callers can switch transports without touching their
+// policy code.
+func ToResult(x *ValidateResponse) rulesclient.Result {
+	res := rulesclient.Result{
+		ConfigurationID: x.GetConfigurationId(),
+		Fingerprint:     x.GetFingerprint(),
+		Violations:      ToViolations(x.GetViolations()),
+		Blocking:        x.GetBlocking(),
+		Packs:           x.GetPacks(),
+		Locale:          x.GetLocale(),
+		Policy:          x.GetPolicy(),
+	}
+	for _, f := range x.GetFixes() {
+		fix := rulesclient.Fix{Changes: make([]rulesclient.Change, 0, len(f.GetChanges()))}
+		for _, c := range f.GetChanges() {
+			fix.Changes = append(fix.Changes, rulesclient.Change{
+				Op:       c.GetOp(),
+				Option:   c.GetOption(),
+				Quantity: int(c.GetQuantity()),
+				Layout:   c.GetLayout(),
+				Finish:   c.GetFinish(),
+			})
+		}
+		res.Fixes = append(res.Fixes, fix)
+	}
+	return res
+}
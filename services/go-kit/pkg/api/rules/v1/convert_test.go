@@ -0,0 +1,95 @@
+package rulesv1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+)
+
+func TestParamsRoundTrip(t *testing.T) {
+	// Numbers come back as float64 and nested values as their JSON shapes,
+	// exactly as the HTTP client decodes them.
+	params := map[string]any{
+		"layout":   "island",
+		"minMM":    900,
+		"ratio":    0.5,
+		"required": true,
+		"options":  []string{"sink", "hob"},
+		"wall":     map[string]any{"id": "north", "lengthMM": 3600},
+	}
+	want := map[string]any{
+		"layout":   "island",
+		"minMM":    float64(900),
+		"ratio":    0.5,
+		"required": true,
+		"options":  []any{"sink", "hob"},
+		"wall":     map[string]any{"id": "north", "lengthMM": float64(3600)},
+	}
+	if got := Params(params).AsMap(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip changed params:\n got %#v\nwant %#v", got, want)
+	}
+	if Params(nil) != nil || Params(map[string]any{}) != nil {
+		t.Fatalf("expected nil and empty params to stay unset")
+	}
+	if Params(map[string]any{"bad": make(chan int)}) != nil {
+		t.Fatalf("expected params without a JSON form to be dropped")
+	}
+}
+
+func TestResultRoundTrip(t *testing.T) {
+	violations := []rulesclient.Violation{
+		{
+			Rule:        "island-clearance",
+			Code:        "room.walkway",
+			Severity:    "error",
+			Message:     "Walkway too narrow",
+			Paths:       []string{"room.island"},
+			Options:     []string{"island-counter"},
+			Params:      map[string]any{"minMM": float64(900)},
+			Overridable: true,
+			AckToken:    "ack-1",
+		},
+		{Code: "appliance.limit", Severity: "warning", Message: "Too many appliances", Acknowledged: true},
+	}
+	resp := &ValidateResponse{
+		ConfigurationId: "cfg-1",
+		Fingerprint:     "fp-1",
+		Violations:      FromViolations(violations),
+		Blocking:        true,
+		Packs:           []string{"gb"},
+		Locale:          "en",
+		Policy:          "showroom-designer",
+		Fixes: []*Fix{{Changes: []*Change{
+			{Op: "remove-option", Option: "island-counter"},
+			{Op: "add-option", Option: "drawer", Quantity: 2},
+			{Op: "set-layout", Layout: "l-shape"},
+			{Op: "set-finish", Finish: "oak"},
+		}}},
+	}
+	want := rulesclient.Result{
+		ConfigurationID: "cfg-1",
+		Fingerprint:     "fp-1",
+		Violations:      violations,
+		Blocking:        true,
+		Packs:           []string{"gb"},
+		Locale:          "en",
+		Policy:          "showroom-designer",
+		Fixes: []rulesclient.Fix{{Changes: []rulesclient.Change{
+			{Op: "remove-option", Option: "island-counter"},
+			{Op: "add-option", Option: "drawer", Quantity: 2},
+			{Op: "set-layout", Layout: "l-shape"},
+			{Op: "set-finish", Finish: "oak"},
+		}}},
+	}
+	if got := ToResult(resp); !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip changed result:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestToResultNil(t *testing.T) {
+	got := ToResult(nil)
+	if got.Blocking || len(got.Violations) != 0 || got.Fixes != nil {
+		t.Fatalf("expected an empty verdict, got %+v", got)
+	}
+}
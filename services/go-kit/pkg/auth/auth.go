@@ -0,0 +1,110 @@
+// Package auth maps bearer API keys to the principal they authenticate.
+// Services configure keys as comma-separated "principal=key" pairs; only the
+// SHA-256 digest of each key is kept, and lookups compare every digest in
+// constant time so timing does not reveal which key nearly matched.
+//
+// What a principal means is up to the service: rules-go maps keys to channel
+// policies, pricing-go to the authors of saved configurations.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnauthorized is returned when a presented key matches no principal.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Keys is an immutable set of API keys. The zero value authenticates nobody.
+type Keys struct {
+	entries []entry
+}
+
+type entry struct {
+	principal string
+	digest    [sha256.Size]byte
+}
+
+// ParseKeys reads "principal=key" pairs separated by commas. Several keys
+// may share a principal so keys can be rotated; a key may not be reused.
+func ParseKeys(spec string) (Keys, error) {
+	var keys Keys
+	seen := make(map[[sha256.Size]byte]struct{})
+	for i, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		principal, key, ok := strings.Cut(pair, "=")
+		principal, key = strings.TrimSpace(principal), strings.TrimSpace(key)
+		if !ok || principal == "" || key == "" {
+			return Keys{}, fmt.Errorf("key pair %d: want principal=key", i+1)
+		}
+		digest := sha256.Sum256([]byte(key))
+		if _, dup := seen[digest]; dup {
+			return Keys{}, fmt.Errorf("principal %s: key is already assigned", principal)
+		}
+		seen[digest] = struct{}{}
+		keys.entries = append(keys.entries, entry{principal: principal, digest: digest})
+	}
+	return keys, nil
+}
+
+// With returns a copy of the set that also maps key to principal. An empty
+// key leaves the set unchanged.
+func (k Keys) With(principal, key string) Keys {
+	if key == "" {
+		return k
+	}
+	entries := make([]entry, 0, len(k.entries)+1)
+	entries = append(entries, k.entries...)
+	entries = append(entries, entry{principal: principal, digest: sha256.Sum256([]byte(key))})
+	return Keys{entries: entries}
+}
+
+// Len reports how many keys are configured.
+func (k Keys) Len() int {
+	return len(k.entries)
+}
+
+// Lookup returns the principal a key authenticates.
+func (k Keys) Lookup(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	digest := sha256.Sum256([]byte(key))
+	principal, found := "", false
+	for _, e := range k.entries {
+		if subtle.ConstantTimeCompare(digest[:], e.digest[:]) == 1 && !found {
+			principal, found = e.principal, true
+		}
+	}
+	return principal, found
+}
+
+// Bearer extracts the token from an "Authorization: Bearer <token>" value,
+// or returns "" when the header carries no bearer token.
+func Bearer(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+type principalKey struct{}
+
+// NewContext returns a context carrying the authenticated principal.
+func NewContext(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal stored by NewContext.
+func FromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
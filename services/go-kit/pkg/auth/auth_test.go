@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseKeysAndLookup(t *testing.T) {
+	keys, err := ParseKeys(" retail-web=k1, showroom-designer=k2 ,retail-web=k3,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys.Len() != 3 {
+		t.Fatalf("expected 3 keys, got %d", keys.Len())
+	}
+	for key, want := range map[string]string{"k1": "retail-web", "k2": "showroom-designer", "k3": "retail-web"} {
+		if got, ok := keys.Lookup(key); !ok || got != want {
+			t.Fatalf("Lookup(%q) = %q, %v; want %q", key, got, ok, want)
+		}
+	}
+	if _, ok := keys.Lookup("k4"); ok {
+		t.Fatalf("expected an unknown key to be rejected")
+	}
+	if _, ok := keys.Lookup(""); ok {
+		t.Fatalf("expected an empty key to be rejected")
+	}
+	if got, ok := keys.With("admin", "root").Lookup("root"); !ok || got != "admin" {
+		t.Fatalf("expected With to add a key, got %q", got)
+	}
+	if keys.With("admin", "").Len() != 3 {
+		t.Fatalf("expected an empty key to be ignored")
+	}
+}
+
+func TestParseKeysRejectsMalformedPairs(t *testing.T) {
+	for _, spec := range []string{"retail-web", "=k1", "retail-web=", "a=k1,b=k1"} {
+		if _, err := ParseKeys(spec); err == nil {
+			t.Fatalf("expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestBearerAndContext(t *testing.T) {
+	if got := Bearer("bearer  abc "); got != "abc" {
+		t.Fatalf("unexpected bearer token %q", got)
+	}
+	if got := Bearer("Basic abc"); got != "" {
+		t.Fatalf("expected non-bearer schemes to be ignored, got %q", got)
+	}
+	ctx := NewContext(context.Background(), "pricing")
+	if got, ok := FromContext(ctx); !ok || got != "pricing" {
+		t.Fatalf("unexpected principal %q", got)
+	}
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("expected no principal on a bare context")
+	}
+}
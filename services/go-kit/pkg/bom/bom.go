@@ -0,0 +1,449 @@
+// Package bom expands a selection into a hierarchical bill of materials.
+// What a configuration is made of lives in data, not code: expansion rules
+// name variables for the run geometry, per-module and per-layout overrides,
+// finish materials and a tree of components whose quantities and sizes refer
+// to those variables. Manufacturing consumes the result as JSON.
+package bom
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+//go:embed rules.json
+var defaultSource []byte
+
+// ErrUnsupported is returned for selections the rules cannot expand, such as
+// an unknown layout.
+var ErrUnsupported = errors.New("selection cannot be expanded")
+
+// Item kinds.
+const (
+	KindAssembly = "assembly"
+	KindCarcass  = "carcass"
+	KindFront    = "front"
+	KindHardware = "hardware"
+	KindPanel    = "panel"
+	KindWorktop  = "worktop"
+)
+
+// optionPrefix marks a per variable that counts a selected option.
+const optionPrefix = "option:"
+
+// maxOptionQuantity bounds an option's total quantity, since option
+// variables multiply part counts.
+const maxOptionQuantity = 100
+
+// maxRunLengthMM bounds a selection's run length, since the length scales
+// part sizes and unit counts.
+const maxRunLengthMM = 20000
+
+// Derived variables computed from the selection and layout.
+const (
+	varRuns            = "runs"
+	varCorners         = "corners"
+	varIslands         = "islands"
+	varRunLengthPerRun = "runLengthPerRunMm"
+	varBaseUnitsPerRun = "baseUnitsPerRun"
+	varWallUnitsPerRun = "wallUnitsPerRun"
+	varIslandUnits     = "islandUnits"
+)
+
+var kinds = map[string]bool{
+	KindAssembly: true, KindCarcass: true, KindFront: true,
+	KindHardware: true, KindPanel: true, KindWorktop: true,
+}
+
+// Size is a part's dimensions in millimetres. Fields a part does not have
+// are zero.
+type Size struct {
+	WidthMM     int `json:"widthMm,omitempty"`
+	HeightMM    int `json:"heightMm,omitempty"`
+	DepthMM     int `json:"depthMm,omitempty"`
+	ThicknessMM int `json:"thicknessMm,omitempty"`
+}
+
+// Item is one node of the bill. Quantity is per parent item; Children are
+// what one unit of the item is built from.
+type Item struct {
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	Part     string `json:"part"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Material string `json:"material,omitempty"`
+	Size     *Size  `json:"size,omitempty"`
+	Children []Item `json:"children,omitempty"`
+}
+
+// Line is a part's total quantity across the whole bill, one line per
+// distinct part, size and material.
+type Line struct {
+	Part     string `json:"part"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Material string `json:"material,omitempty"`
+	Size     *Size  `json:"size,omitempty"`
+}
+
+// BOM is the expanded bill for one selection. Unexpanded lists selected
+// options no rule produces parts for.
+type BOM struct {
+	RulesVersion    string   `json:"rulesVersion"`
+	ConfigurationID string   `json:"configurationId,omitempty"`
+	Fingerprint     string   `json:"fingerprint"`
+	Module          string   `json:"module"`
+	Layout          string   `json:"layout"`
+	Finish          string   `json:"finish,omitempty"`
+	Items           []Item   `json:"items"`
+	Parts           []Line   `json:"parts"`
+	Unexpanded      []string `json:"unexpanded"`
+}
+
+// measure is a size given as a literal number of millimetres or the name of
+// a variable.
+type measure struct {
+	value int
+	name  string
+}
+
+func (m *measure) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &m.name); err == nil {
+		return nil
+	}
+	return json.Unmarshal(b, &m.value)
+}
+
+type sizeRule struct {
+	Width     *measure `json:"width"`
+	Height    *measure `json:"height"`
+	Depth     *measure `json:"depth"`
+	Thickness *measure `json:"thickness"`
+}
+
+// component is one expansion rule. The item's quantity is Quantity (default
+// 1) times the value of Per (default 1) per parent item.
+type component struct {
+	ID       string      `json:"id"`
+	Kind     string      `json:"kind"`
+	Part     string      `json:"part"`
+	Name     string      `json:"name"`
+	Per      string      `json:"per"`
+	Quantity int         `json:"quantity"`
+	Finished bool        `json:"finished"`
+	Size     *sizeRule   `json:"size"`
+	Children []component `json:"children"`
+}
+
+type layoutRule struct {
+	Runs    int `json:"runs"`
+	Corners int `json:"corners"`
+	Islands int `json:"islands"`
+}
+
+// Rules is a parsed, validated set of expansion rules. It is immutable and
+// safe for concurrent use.
+type Rules struct {
+	version    string
+	variables  map[string]int
+	modules    map[string]map[string]int
+	layouts    map[string]layoutRule
+	finishes   map[string]string
+	components []component
+}
+
+var defaultRules *Rules
+
+func init() {
+	r, err := Parse(bytes.NewReader(defaultSource))
+	if err != nil {
+		panic(fmt.Sprintf("embedded BOM rules are invalid: %v", err))
+	}
+	defaultRules = r
+}
+
+// Default returns the rules embedded from rules.json.
+func Default() *Rules {
+	return defaultRules
+}
+
+// LoadFile reads rules from disk.
+func LoadFile(path string) (*Rules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse decodes and validates expansion rules. Every per and size reference
+// must name a variable, a derived variable or an option.
+func Parse(r io.Reader) (*Rules, error) {
+	var src struct {
+		Version    string                    `json:"version"`
+		Variables  map[string]int            `json:"variables"`
+		Modules    map[string]map[string]int `json:"modules"`
+		Layouts    map[string]layoutRule     `json:"layouts"`
+		Finishes   map[string]string         `json:"finishes"`
+		Components []component               `json:"components"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&src); err != nil {
+		return nil, fmt.Errorf("decode BOM rules: %w", err)
+	}
+	if src.Version == "" {
+		return nil, errors.New("BOM rules need a version")
+	}
+	rules := &Rules{
+		version:    src.Version,
+		variables:  src.Variables,
+		modules:    src.Modules,
+		layouts:    src.Layouts,
+		finishes:   src.Finishes,
+		components: src.Components,
+	}
+	for module, vars := range rules.modules {
+		for name := range vars {
+			if _, ok := rules.variables[name]; !ok {
+				return nil, fmt.Errorf("module %s overrides unknown variable %q", module, name)
+			}
+		}
+	}
+	for name, l := range rules.layouts {
+		if l.Runs < 1 || l.Corners < 0 || l.Islands < 0 {
+			return nil, fmt.Errorf("layout %s: needs at least one run", name)
+		}
+	}
+	seen := make(map[string]bool)
+	if err := rules.check(rules.components, seen); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Version identifies the rule set a bill was expanded with.
+func (r *Rules) Version() string {
+	return r.version
+}
+
+func (r *Rules) check(components []component, seen map[string]bool) error {
+	for _, c := range components {
+		if c.ID == "" || seen[c.ID] {
+			return fmt.Errorf("component %q: ids must be present and unique", c.ID)
+		}
+		seen[c.ID] = true
+		if !kinds[c.Kind] {
+			return fmt.Errorf("component %s: unknown kind %q", c.ID, c.Kind)
+		}
+		if c.Quantity < 0 {
+			return fmt.Errorf("component %s: quantity must be >= 0", c.ID)
+		}
+		refs := []string{c.Per}
+		if c.Size != nil {
+			for _, m := range []*measure{c.Size.Width, c.Size.Height, c.Size.Depth, c.Size.Thickness} {
+				if m != nil {
+					refs = append(refs, m.name)
+				}
+			}
+		}
+		for _, ref := range refs {
+			if ref != "" && !r.known(ref) {
+				return fmt.Errorf("component %s: unknown variable %q", c.ID, ref)
+			}
+		}
+		if err := r.check(c.Children, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Rules) known(name string) bool {
+	if strings.HasPrefix(name, optionPrefix) && len(name) > len(optionPrefix) {
+		return true
+	}
+	if _, ok := r.variables[name]; ok {
+		return true
+	}
+	switch name {
+	case varRuns, varCorners, varIslands, varRunLengthPerRun, varBaseUnitsPerRun, varWallUnitsPerRun, varIslandUnits:
+		return true
+	}
+	return false
+}
+
+// Expand builds the bill for a selection. The run length comes from the
+// selection's dimensions when given, otherwise from the module's defaults.
+func (r *Rules) Expand(sel configurator.Selection) (BOM, error) {
+	if err := sel.Validate(configurator.FieldLayout); err != nil {
+		return BOM{}, err
+	}
+	if _, ok := r.modules[sel.Module]; !ok {
+		return BOM{}, fmt.Errorf("%w: unknown module %q", ErrUnsupported, sel.Module)
+	}
+	layout, ok := r.layouts[sel.Layout]
+	if !ok {
+		return BOM{}, fmt.Errorf("%w: unknown layout %q", ErrUnsupported, sel.Layout)
+	}
+	if sel.Dimensions.LengthMM > maxRunLengthMM {
+		return BOM{}, fmt.Errorf("%w: run of %dmm exceeds %dmm", ErrUnsupported, sel.Dimensions.LengthMM, maxRunLengthMM)
+	}
+	material, ok := r.finishes[sel.Finish]
+	if !ok && sel.Finish != "" {
+		return BOM{}, fmt.Errorf("%w: unknown finish %q", ErrUnsupported, sel.Finish)
+	}
+	vars := r.resolveVariables(sel, layout)
+	for _, opt := range sel.Options {
+		if q := vars[optionPrefix+opt.ID]; q > maxOptionQuantity {
+			return BOM{}, fmt.Errorf("%w: option %s quantity %d exceeds %d", ErrUnsupported, opt.ID, q, maxOptionQuantity)
+		}
+	}
+	if vars[varRunLengthPerRun] < vars["unitWidthMm"] {
+		return BOM{}, fmt.Errorf("%w: run of %dmm is too short for layout %s", ErrUnsupported, vars["runLengthMm"], sel.Layout)
+	}
+
+	out := BOM{
+		RulesVersion:    r.version,
+		ConfigurationID: sel.ConfigurationID,
+		Fingerprint:     sel.Fingerprint(),
+		Module:          sel.Module,
+		Layout:          sel.Layout,
+		Finish:          sel.Finish,
+		Parts:           make([]Line, 0),
+		Unexpanded:      make([]string, 0),
+	}
+	used := make(map[string]bool)
+	out.Items = r.expand(r.components, vars, material, used)
+	out.Parts = flatten(out.Items)
+	for _, opt := range sel.Options {
+		if opt.Quantity > 0 && !used[optionPrefix+opt.ID] && !contains(out.Unexpanded, opt.ID) {
+			out.Unexpanded = append(out.Unexpanded, opt.ID)
+		}
+	}
+	return out, nil
+}
+
+// resolveVariables resolves every variable for the selection: defaults, module
+// overrides, the selection's length, option quantities and the derived run
+// geometry.
+func (r *Rules) resolveVariables(sel configurator.Selection, layout layoutRule) map[string]int {
+	vars := make(map[string]int, len(r.variables)+len(sel.Options)+7)
+	for k, v := range r.variables {
+		vars[k] = v
+	}
+	for k, v := range r.modules[sel.Module] {
+		vars[k] = v
+	}
+	if sel.Dimensions.LengthMM > 0 {
+		vars["runLengthMm"] = sel.Dimensions.LengthMM
+	}
+	for _, opt := range sel.Options {
+		vars[optionPrefix+opt.ID] += opt.Quantity
+	}
+
+	vars[varRuns] = layout.Runs
+	vars[varCorners] = layout.Corners
+	vars[varIslands] = layout.Islands
+	perRun := (vars["runLengthMm"] - layout.Corners*vars["cornerWidthMm"]) / layout.Runs
+	vars[varRunLengthPerRun] = max(perRun, 0)
+	if w := vars["unitWidthMm"]; w > 0 {
+		vars[varBaseUnitsPerRun] = vars[varRunLengthPerRun] / w
+		vars[varIslandUnits] = vars["islandLengthMm"] / w
+	}
+	vars[varWallUnitsPerRun] = vars[varBaseUnitsPerRun] * vars["wallUnits"]
+	return vars
+}
+
+func (r *Rules) expand(components []component, vars map[string]int, material string, used map[string]bool) []Item {
+	items := make([]Item, 0, len(components))
+	for _, c := range components {
+		qty := c.Quantity
+		if qty == 0 {
+			qty = 1
+		}
+		if c.Per != "" {
+			qty *= vars[c.Per]
+		}
+		if qty <= 0 {
+			continue
+		}
+		if strings.HasPrefix(c.Per, optionPrefix) {
+			used[c.Per] = true
+		}
+		item := Item{ID: c.ID, Kind: c.Kind, Part: c.Part, Name: c.Name, Quantity: qty}
+		if c.Finished {
+			item.Material = material
+		}
+		if c.Size != nil {
+			item.Size = &Size{
+				WidthMM:     resolve(c.Size.Width, vars),
+				HeightMM:    resolve(c.Size.Height, vars),
+				DepthMM:     resolve(c.Size.Depth, vars),
+				ThicknessMM: resolve(c.Size.Thickness, vars),
+			}
+		}
+		if len(c.Children) > 0 {
+			item.Children = r.expand(c.Children, vars, material, used)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func resolve(m *measure, vars map[string]int) int {
+	switch {
+	case m == nil:
+		return 0
+	case m.name != "":
+		return vars[m.name]
+	}
+	return m.value
+}
+
+// flatten totals parts across the tree, multiplying quantities down each
+// branch. Lines keep first-seen order. Assemblies are groupings, not parts.
+func flatten(items []Item) []Line {
+	lines := make([]Line, 0)
+	index := make(map[string]int)
+	var walk func(items []Item, multiplier int)
+	walk = func(items []Item, multiplier int) {
+		for _, it := range items {
+			total := it.Quantity * multiplier
+			if it.Kind != KindAssembly {
+				key := it.Part + "\x00" + it.Material
+				if it.Size != nil {
+					key += fmt.Sprintf("\x00%d\x00%d\x00%d\x00%d", it.Size.WidthMM, it.Size.HeightMM, it.Size.DepthMM, it.Size.ThicknessMM)
+				}
+				if i, ok := index[key]; ok {
+					lines[i].Quantity += total
+				} else {
+					index[key] = len(lines)
+					lines = append(lines, Line{Part: it.Part, Kind: it.Kind, Name: it.Name, Quantity: total, Material: it.Material, Size: it.Size})
+				}
+			}
+			walk(it.Children, total)
+		}
+	}
+	walk(items, 1)
+	return lines
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
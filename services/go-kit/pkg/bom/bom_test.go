@@ -0,0 +1,107 @@
+package bom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+func totals(b BOM) map[string]int {
+	out := make(map[string]int)
+	for _, l := range b.Parts {
+		out[l.Part] += l.Quantity
+	}
+	return out
+}
+
+func TestExpandLinearGalley(t *testing.T) {
+	b, err := Default().Expand(configurator.Selection{Module: "galley", Layout: "linear", Finish: "gloss"})
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	if b.RulesVersion == "" || b.Fingerprint == "" || len(b.Items) != 1 || b.Items[0].Kind != KindAssembly {
+		t.Fatalf("expected one base run, got %+v", b)
+	}
+	carcass := b.Items[0].Children[0]
+	if carcass.Part != "CAR-B600" || carcass.Quantity != 5 || carcass.Size.WidthMM != 600 || carcass.Size.ThicknessMM != 18 {
+		t.Fatalf("expected five 600mm base carcasses on a 3m run, got %+v", carcass)
+	}
+	if front := carcass.Children[0]; front.Material != "MAT-GLOSS-19" || front.Quantity != 1 {
+		t.Fatalf("fronts should carry the finish material, got %+v", front)
+	}
+
+	got := totals(b)
+	want := map[string]int{"CAR-B600": 5, "CAR-W600": 5, "HW-HINGE-110": 20, "HW-LEG-150": 20, "PNL-END": 2, "WT-LAM-30": 1}
+	for part, qty := range want {
+		if got[part] != qty {
+			t.Fatalf("part %s: got %d want %d (%+v)", part, got[part], qty, got)
+		}
+	}
+}
+
+func TestExpandLayoutsAndOptions(t *testing.T) {
+	b, err := Default().Expand(configurator.Selection{
+		Module:     "galley",
+		Layout:     "island",
+		Finish:     "matte",
+		Dimensions: configurator.Dimensions{LengthMM: 2400},
+		Options:    []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}, {ID: "drawer-lighting", Quantity: 3}, {ID: "mystery", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	got := totals(b)
+	// Four base units on the 2.4m run plus three in the island.
+	if got["CAR-B600"] != 7 || got["WT-ISL-CTR"] != 1 || got["HW-LED-DRW"] != 3 || got["PNL-BACK-I"] != 1 {
+		t.Fatalf("unexpected island totals %+v", got)
+	}
+	if len(b.Unexpanded) != 1 || b.Unexpanded[0] != "mystery" {
+		t.Fatalf("expected the unknown option to be reported, got %v", b.Unexpanded)
+	}
+
+	b, err = Default().Expand(configurator.Selection{Module: "pantry", Layout: "l-shape", Dimensions: configurator.Dimensions{LengthMM: 3300}})
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	got = totals(b)
+	if got["CAR-C900"] != 1 || got["CAR-B600"] != 4 || got["CAR-W600"] != 0 {
+		t.Fatalf("expected a corner unit, two base units per run and no wall units, got %+v", got)
+	}
+}
+
+func TestExpandRejectsUnsupportedSelections(t *testing.T) {
+	if _, err := Default().Expand(configurator.Selection{Module: "nonexistent", Layout: "linear"}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected an unknown module to be unsupported, got %v", err)
+	}
+	if _, err := Default().Expand(configurator.Selection{Module: "galley", Layout: "spiral"}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected an unknown layout to be unsupported, got %v", err)
+	}
+	if _, err := Default().Expand(configurator.Selection{Module: "galley", Layout: "linear", Finish: "plaid"}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected an unknown finish to be unsupported, got %v", err)
+	}
+	many := configurator.Selection{Module: "galley", Layout: "linear", Finish: "matte", Options: []configurator.SelectionOption{
+		{ID: "drawer-lighting", Quantity: maxOptionQuantity},
+		{ID: "drawer-lighting", Quantity: 1},
+	}}
+	if _, err := Default().Expand(many); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected an option quantity over %d to be unsupported, got %v", maxOptionQuantity, err)
+	}
+	short := configurator.Selection{Module: "galley", Layout: "u-shape", Dimensions: configurator.Dimensions{LengthMM: 2000}}
+	if _, err := Default().Expand(short); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected a too-short run to be unsupported, got %v", err)
+	}
+	long := configurator.Selection{Module: "galley", Layout: "linear", Dimensions: configurator.Dimensions{LengthMM: maxRunLengthMM + 1}}
+	if _, err := Default().Expand(long); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected a run over %dmm to be unsupported, got %v", maxRunLengthMM, err)
+	}
+}
+
+func TestParseRejectsUnknownVariables(t *testing.T) {
+	src := `{"version":"t","variables":{},"layouts":{"linear":{"runs":1}},
+		"components":[{"id":"a","kind":"carcass","part":"X","per":"nope"}]}`
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Fatalf("expected an unknown per variable to be rejected")
+	}
+}
@@ -6,12 +6,43 @@ import (
 	"time"
 )
 
-// ErrCacheMiss indicates the key was not found.
-var ErrCacheMiss = errors.New("cache miss")
+var (
+	// ErrCacheMiss indicates the key was not found.
+	ErrCacheMiss = errors.New("cache miss")
+	// ErrConflict is returned when a guarded write finds its key already
+	// present or changed; none of the batch's writes are applied.
+	ErrConflict = errors.New("cache conflict")
+)
 
 // Cache defines the minimal surface the Go services rely on so we can
 // swap Redis for in-memory implementations during tests.
 type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	// Commit applies every write or none of them (MULTI/EXEC on Redis).
+	Commit(ctx context.Context, writes ...Write) error
+	// List returns every value appended to key, oldest first, or an empty
+	// slice when the list does not exist.
+	List(ctx context.Context, key string) ([]string, error)
+}
+
+// Write is one mutation in a Commit batch.
+type Write struct {
+	Key   string
+	Value string
+	// TTL expires the key; zero keeps it forever. For lists it resets the
+	// expiry of the whole list.
+	TTL time.Duration
+	// Append pushes Value onto the list at Key (RPUSH) instead of
+	// replacing the key's value.
+	Append bool
+	// Keep trims an appended list to its last Keep values (LTRIM) in the
+	// same batch; zero keeps every value.
+	Keep int
+	// IfAbsent aborts the whole batch with ErrConflict when Key exists.
+	IfAbsent bool
+	// IfUnchanged aborts the whole batch with ErrConflict unless Key still
+	// holds Previous, making the write a compare-and-swap.
+	IfUnchanged bool
+	Previous    string
 }
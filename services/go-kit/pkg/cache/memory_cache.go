@@ -8,9 +8,14 @@ import (
 
 type memoryEntry struct {
 	value     string
+	list      []string
 	expiresAt time.Time
 }
 
+func (e memoryEntry) expired(now time.Time) bool {
+	return e.expiresAt != (time.Time{}) && now.After(e.expiresAt)
+}
+
 // NewMemoryCache returns an in-memory cache that is safe for concurrent use.
 func NewMemoryCache() Cache {
 	return &memoryCache{data: make(map[string]memoryEntry)}
@@ -25,7 +30,7 @@ func (m *memoryCache) Get(_ context.Context, key string) (string, error) {
 	m.mu.RLock()
 	entry, ok := m.data[key]
 	m.mu.RUnlock()
-	if !ok || (entry.expiresAt != (time.Time{}) && time.Now().After(entry.expiresAt)) {
+	if !ok || entry.list != nil || entry.expired(time.Now()) {
 		return "", ErrCacheMiss
 	}
 	return entry.value, nil
@@ -33,11 +38,60 @@ func (m *memoryCache) Get(_ context.Context, key string) (string, error) {
 
 func (m *memoryCache) Set(_ context.Context, key, value string, ttl time.Duration) error {
 	m.mu.Lock()
-	var expires time.Time
-	if ttl > 0 {
-		expires = time.Now().Add(ttl)
-	}
-	m.data[key] = memoryEntry{value: value, expiresAt: expires}
+	m.data[key] = memoryEntry{value: value, expiresAt: expiry(ttl)}
 	m.mu.Unlock()
 	return nil
 }
+
+func (m *memoryCache) Commit(_ context.Context, writes ...Write) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for _, w := range writes {
+		entry, ok := m.data[w.Key]
+		live := ok && !entry.expired(now)
+		if w.IfAbsent && live {
+			return ErrConflict
+		}
+		if w.IfUnchanged && (!live || entry.list != nil || entry.value != w.Previous) {
+			return ErrConflict
+		}
+	}
+	for _, w := range writes {
+		if !w.Append {
+			m.data[w.Key] = memoryEntry{value: w.Value, expiresAt: expiry(w.TTL)}
+			continue
+		}
+		entry := m.data[w.Key]
+		if entry.expired(now) {
+			entry = memoryEntry{}
+		}
+		entry.value = ""
+		entry.list = append(append([]string{}, entry.list...), w.Value)
+		if w.Keep > 0 && len(entry.list) > w.Keep {
+			entry.list = entry.list[len(entry.list)-w.Keep:]
+		}
+		if w.TTL > 0 {
+			entry.expiresAt = expiry(w.TTL)
+		}
+		m.data[w.Key] = entry
+	}
+	return nil
+}
+
+func (m *memoryCache) List(_ context.Context, key string) ([]string, error) {
+	m.mu.RLock()
+	entry, ok := m.data[key]
+	m.mu.RUnlock()
+	if !ok || entry.expired(time.Now()) {
+		return []string{}, nil
+	}
+	return append([]string{}, entry.list...), nil
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl > 0 {
+		return time.Now().Add(ttl)
+	}
+	return time.Time{}
+}
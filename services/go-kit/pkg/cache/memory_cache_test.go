@@ -26,3 +26,75 @@ func TestMemoryCacheGetSet(t *testing.T) {
 		t.Fatalf("expected cache miss after expiration")
 	}
 }
+
+func TestMemoryCacheCommitIsAllOrNothing(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+	if err := cache.Commit(ctx,
+		Write{Key: "set:v1", Value: "rules", IfAbsent: true},
+		Write{Key: "log", Value: "v1", Append: true},
+		Write{Key: "active", Value: "v1"},
+	); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+
+	err := cache.Commit(ctx,
+		Write{Key: "set:v1", Value: "other", IfAbsent: true},
+		Write{Key: "log", Value: "v1", Append: true},
+		Write{Key: "active", Value: "v2"},
+	)
+	if err != ErrConflict {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if active, _ := cache.Get(ctx, "active"); active != "v1" {
+		t.Fatalf("a conflicting batch must not apply any write, active is %q", active)
+	}
+
+	if err := cache.Commit(ctx, Write{Key: "log", Value: "v2", Append: true}); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+	log, err := cache.List(ctx, "log")
+	if err != nil || len(log) != 2 || log[0] != "v1" || log[1] != "v2" {
+		t.Fatalf("unexpected list %v (%v)", log, err)
+	}
+	if empty, err := cache.List(ctx, "missing"); err != nil || len(empty) != 0 {
+		t.Fatalf("expected an empty list, got %v (%v)", empty, err)
+	}
+}
+
+func TestMemoryCacheCommitComparesAndSwaps(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+	if err := cache.Set(ctx, "session", "r1", 0); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	if err := cache.Commit(ctx, Write{Key: "session", Value: "r2", IfUnchanged: true, Previous: "r1"}); err != nil {
+		t.Fatalf("swap failed: %v", err)
+	}
+	if err := cache.Commit(ctx, Write{Key: "session", Value: "r2b", IfUnchanged: true, Previous: "r1"}); err != ErrConflict {
+		t.Fatalf("expected a stale swap to conflict, got %v", err)
+	}
+	if got, _ := cache.Get(ctx, "session"); got != "r2" {
+		t.Fatalf("a conflicting swap must not write, got %q", got)
+	}
+	if err := cache.Commit(ctx, Write{Key: "missing", Value: "x", IfUnchanged: true}); err != ErrConflict {
+		t.Fatalf("expected a swap on a missing key to conflict, got %v", err)
+	}
+}
+
+func TestMemoryCacheCommitTrimsAppendedLists(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+	if err := cache.Commit(ctx,
+		Write{Key: "samples", Value: "a", Append: true, Keep: 2},
+		Write{Key: "samples", Value: "b", Append: true, Keep: 2},
+		Write{Key: "samples", Value: "c", Append: true, Keep: 2},
+	); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+	list, err := cache.List(ctx, "samples")
+	if err != nil || len(list) != 2 || list[0] != "b" || list[1] != "c" {
+		t.Fatalf("expected the two most recent values, got %v (%v)", list, err)
+	}
+}
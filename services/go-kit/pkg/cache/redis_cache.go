@@ -56,6 +56,72 @@ func (r *redisCache) Set(ctx context.Context, key, value string, ttl time.Durati
 	return r.client.Set(ctx, key, value, ttl).Err()
 }
 
+func (r *redisCache) Commit(ctx context.Context, writes ...Write) error {
+	var absent, guarded []string
+	for _, w := range writes {
+		if w.IfAbsent {
+			absent = append(absent, w.Key)
+		}
+		if w.IfAbsent || w.IfUnchanged {
+			guarded = append(guarded, w.Key)
+		}
+	}
+	apply := func(tx *redis.Tx) error {
+		if len(absent) > 0 {
+			n, err := tx.Exists(ctx, absent...).Result()
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				return ErrConflict
+			}
+		}
+		for _, w := range writes {
+			if !w.IfUnchanged {
+				continue
+			}
+			current, err := tx.Get(ctx, w.Key).Result()
+			if errors.Is(err, redis.Nil) {
+				return ErrConflict
+			}
+			if err != nil {
+				return err
+			}
+			if current != w.Previous {
+				return ErrConflict
+			}
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, w := range writes {
+				if !w.Append {
+					pipe.Set(ctx, w.Key, w.Value, w.TTL)
+					continue
+				}
+				pipe.RPush(ctx, w.Key, w.Value)
+				if w.Keep > 0 {
+					pipe.LTrim(ctx, w.Key, int64(-w.Keep), -1)
+				}
+				if w.TTL > 0 {
+					pipe.Expire(ctx, w.Key, w.TTL)
+				}
+			}
+			return nil
+		})
+		return err
+	}
+	// WATCH the guarded keys so a concurrent writer aborts EXEC instead of
+	// slipping in between the checks and the writes.
+	err := r.client.Watch(ctx, apply, guarded...)
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (r *redisCache) List(ctx context.Context, key string) ([]string, error) {
+	return r.client.LRange(ctx, key, 0, -1).Result()
+}
+
 // Close releases the underlying Redis client.
 func (r *redisCache) Close() error {
 	return r.client.Close()
@@ -0,0 +1,204 @@
+// Package catalog is the single source of truth for configurator options:
+// their IDs, display names, categories, tags and allowed quantities. Rules
+// and pricing both read it so category-level behaviour never drifts between
+// services. The package also maps the gateway catalog's module and finish
+// IDs onto the keys the Go price books use.
+package catalog
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Categories shipped in the default catalog.
+const (
+	CategoryAppliance = "appliance"
+	CategoryWorktop   = "worktop"
+	CategoryCabinetry = "cabinetry"
+	CategoryStorage   = "storage"
+	CategoryLighting  = "lighting"
+	CategoryWall      = "wall"
+)
+
+//go:embed options.json
+var defaultSource []byte
+
+// Category groups options for rules and price line items.
+type Category struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Option describes one selectable option. A zero MaxQuantity means no upper
+// bound.
+type Option struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Tags        []string `json:"tags,omitempty"`
+	MinQuantity int      `json:"minQuantity"`
+	MaxQuantity int      `json:"maxQuantity,omitempty"`
+}
+
+// AllowsQuantity reports whether q is within the option's bounds.
+func (o Option) AllowsQuantity(q int) bool {
+	if q < o.MinQuantity {
+		return false
+	}
+	return o.MaxQuantity == 0 || q <= o.MaxQuantity
+}
+
+// HasTag reports whether the option carries the tag.
+func (o Option) HasTag(tag string) bool {
+	for _, t := range o.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog is an immutable, ordered set of options. It is safe for concurrent
+// use.
+type Catalog struct {
+	categories []Category
+	options    []Option
+	byID       map[string]int
+	byCategory map[string][]string
+	names      map[string]string
+}
+
+var defaultCatalog *Catalog
+
+func init() {
+	c, err := Parse(bytes.NewReader(defaultSource))
+	if err != nil {
+		panic(fmt.Sprintf("embedded option catalog is invalid: %v", err))
+	}
+	defaultCatalog = c
+}
+
+// Default returns the catalog embedded from options.json.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// LoadFile reads a catalog from disk.
+func LoadFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse decodes and validates a JSON catalog.
+func Parse(r io.Reader) (*Catalog, error) {
+	var src struct {
+		Categories []Category `json:"categories"`
+		Options    []Option   `json:"options"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&src); err != nil {
+		return nil, fmt.Errorf("decode catalog: %w", err)
+	}
+
+	c := &Catalog{
+		categories: src.Categories,
+		options:    src.Options,
+		byID:       make(map[string]int, len(src.Options)),
+		byCategory: make(map[string][]string, len(src.Categories)),
+		names:      make(map[string]string, len(src.Categories)),
+	}
+	for _, cat := range src.Categories {
+		if cat.ID == "" {
+			return nil, errors.New("category id is required")
+		}
+		if _, dup := c.names[cat.ID]; dup {
+			return nil, fmt.Errorf("category %s is defined twice", cat.ID)
+		}
+		c.names[cat.ID] = cat.Name
+	}
+	for i, opt := range src.Options {
+		if opt.ID == "" {
+			return nil, fmt.Errorf("option %d: id is required", i)
+		}
+		if _, dup := c.byID[opt.ID]; dup {
+			return nil, fmt.Errorf("option %s is defined twice", opt.ID)
+		}
+		if _, ok := c.names[opt.Category]; !ok {
+			return nil, fmt.Errorf("option %s: unknown category %q", opt.ID, opt.Category)
+		}
+		if opt.MinQuantity < 0 || (opt.MaxQuantity != 0 && opt.MaxQuantity < opt.MinQuantity) {
+			return nil, fmt.Errorf("option %s: invalid quantity bounds", opt.ID)
+		}
+		c.byID[opt.ID] = i
+		c.byCategory[opt.Category] = append(c.byCategory[opt.Category], opt.ID)
+	}
+	return c, nil
+}
+
+// Lookup returns the option with the given ID.
+func (c *Catalog) Lookup(id string) (Option, bool) {
+	if i, ok := c.byID[id]; ok {
+		return c.options[i], true
+	}
+	return Option{}, false
+}
+
+// Options returns every option in catalog order.
+func (c *Catalog) Options() []Option {
+	return append([]Option(nil), c.options...)
+}
+
+// IDs returns every option ID in catalog order.
+func (c *Catalog) IDs() []string {
+	ids := make([]string, 0, len(c.options))
+	for _, opt := range c.options {
+		ids = append(ids, opt.ID)
+	}
+	return ids
+}
+
+// Categories returns every category in catalog order.
+func (c *Catalog) Categories() []Category {
+	return append([]Category(nil), c.categories...)
+}
+
+// HasCategory reports whether the category is defined.
+func (c *Catalog) HasCategory(id string) bool {
+	_, ok := c.names[id]
+	return ok
+}
+
+// CategoryName returns the display name of a category, or its ID when the
+// category is unknown.
+func (c *Catalog) CategoryName(id string) string {
+	if name, ok := c.names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
+// InCategory returns the IDs of the options in a category, in catalog order.
+func (c *Catalog) InCategory(category string) []string {
+	return append([]string(nil), c.byCategory[category]...)
+}
+
+// WithTag returns the IDs of the options carrying the tag, in catalog order.
+func (c *Catalog) WithTag(tag string) []string {
+	ids := make([]string, 0)
+	for _, opt := range c.options {
+		if opt.HasTag(tag) {
+			ids = append(ids, opt.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultCatalogGroupsAppliances(t *testing.T) {
+	c := Default()
+	got := c.InCategory(CategoryAppliance)
+	want := []string{"appliance-panel", "range-upgrade", "cooktop-induction"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected appliances %v, got %v", want, got)
+	}
+	opt, ok := c.Lookup("range-upgrade")
+	if !ok || opt.Name == "" || !opt.HasTag("cooking") {
+		t.Fatalf("unexpected option %+v", opt)
+	}
+	if c.CategoryName(CategoryAppliance) != "Appliances" {
+		t.Fatalf("unexpected category name %q", c.CategoryName(CategoryAppliance))
+	}
+}
+
+func TestAllowsQuantity(t *testing.T) {
+	opt := Option{MinQuantity: 1, MaxQuantity: 2}
+	if opt.AllowsQuantity(0) || !opt.AllowsQuantity(2) || opt.AllowsQuantity(3) {
+		t.Fatalf("quantity bounds not enforced")
+	}
+	if !(Option{MinQuantity: 1}).AllowsQuantity(100) {
+		t.Fatalf("zero max should be unbounded")
+	}
+}
+
+func TestParseRejectsUnknownCategory(t *testing.T) {
+	src := `{"categories":[{"id":"a","name":"A"}],"options":[{"id":"x","category":"b","minQuantity":1}]}`
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Fatalf("expected unknown category to be rejected")
+	}
+}
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//go:embed aliases.json
+var defaultAliasSource []byte
+
+// GatewayModule mirrors the gateway's CatalogModuleDTO. Only the fields the
+// Go services join on are decoded; presentation fields such as sprites are
+// ignored.
+type GatewayModule struct {
+	ID        string          `json:"id"`
+	SKU       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Category  string          `json:"category"`
+	BasePrice float64         `json:"basePrice"`
+	Finishes  []GatewayFinish `json:"finishes"`
+}
+
+// GatewayFinish mirrors the gateway's FinishOptionDTO.
+type GatewayFinish struct {
+	ID            string  `json:"id"`
+	Label         string  `json:"label"`
+	CostDelta     float64 `json:"costDelta"`
+	LeadTimeWeeks int     `json:"leadTimeWeeks"`
+}
+
+// ParseGatewayExport decodes a JSON dump of the gateway catalog, an array of
+// CatalogModuleDTO.
+func ParseGatewayExport(r io.Reader) ([]GatewayModule, error) {
+	var modules []GatewayModule
+	if err := json.NewDecoder(r).Decode(&modules); err != nil {
+		return nil, fmt.Errorf("decode gateway catalog: %w", err)
+	}
+	seen := make(map[string]struct{}, len(modules))
+	for i, m := range modules {
+		if m.ID == "" {
+			return nil, fmt.Errorf("module %d: id is required", i)
+		}
+		if _, dup := seen[m.ID]; dup {
+			return nil, fmt.Errorf("module %s is exported twice", m.ID)
+		}
+		seen[m.ID] = struct{}{}
+		for j, f := range m.Finishes {
+			if f.ID == "" {
+				return nil, fmt.Errorf("module %s finish %d: id is required", m.ID, j)
+			}
+		}
+	}
+	return modules, nil
+}
+
+// Aliases maps gateway module IDs or SKUs and gateway finish IDs to the keys
+// the Go price books and rules use, such as mod-galley-s to galley.
+type Aliases struct {
+	Modules  map[string]string `json:"modules"`
+	Finishes map[string]string `json:"finishes"`
+}
+
+var defaultAliases Aliases
+
+func init() {
+	a, err := ParseAliases(bytes.NewReader(defaultAliasSource))
+	if err != nil {
+		panic(fmt.Sprintf("embedded catalog aliases are invalid: %v", err))
+	}
+	defaultAliases = a
+}
+
+// DefaultAliases returns the aliases embedded from aliases.json.
+func DefaultAliases() Aliases {
+	return defaultAliases
+}
+
+// ParseAliases decodes and validates an alias table.
+func ParseAliases(r io.Reader) (Aliases, error) {
+	var a Aliases
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&a); err != nil {
+		return Aliases{}, fmt.Errorf("decode aliases: %w", err)
+	}
+	for _, table := range []map[string]string{a.Modules, a.Finishes} {
+		for from, to := range table {
+			if from == "" || to == "" {
+				return Aliases{}, errors.New("aliases must map a non-empty id to a non-empty key")
+			}
+		}
+	}
+	return a, nil
+}
+
+// Module resolves a gateway module ID or SKU to a price book module key.
+func (a Aliases) Module(idOrSKU string) (string, bool) {
+	key, ok := a.Modules[idOrSKU]
+	return key, ok
+}
+
+// Finish resolves a gateway finish ID to a price book finish key.
+func (a Aliases) Finish(id string) (string, bool) {
+	key, ok := a.Finishes[id]
+	return key, ok
+}
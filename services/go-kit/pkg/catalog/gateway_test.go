@@ -0,0 +1,38 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGatewayExportIgnoresPresentationFields(t *testing.T) {
+	src := `[{"id":"mod-galley-s","sku":"PVZ-GAL-S","name":"Galley S","category":"GALLEY","basePrice":52000,
+		"heroImageUrl":"https://example.test/a.png","spriteLayers":[{"id":"s1"}],
+		"finishes":[{"id":"finish-graphite","label":"Graphite Matte","costDelta":2400,"leadTimeWeeks":8}]}]`
+	modules, err := ParseGatewayExport(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(modules) != 1 || modules[0].SKU != "PVZ-GAL-S" || modules[0].Finishes[0].CostDelta != 2400 {
+		t.Fatalf("unexpected modules %+v", modules)
+	}
+
+	if _, err := ParseGatewayExport(strings.NewReader(`[{"id":"a"},{"id":"a"}]`)); err == nil {
+		t.Fatalf("expected duplicate module ids to be rejected")
+	}
+}
+
+func TestDefaultAliasesResolveIDsAndSKUs(t *testing.T) {
+	a := DefaultAliases()
+	for _, id := range []string{"mod-galley-s", "PVZ-GAL-S"} {
+		if key, ok := a.Module(id); !ok || key != "galley" {
+			t.Fatalf("expected %s to resolve to galley, got %q", id, key)
+		}
+	}
+	if key, ok := a.Finish("finish-polar"); !ok || key != "gloss" {
+		t.Fatalf("expected finish-polar to resolve to gloss, got %q", key)
+	}
+	if _, ok := a.Finish("finish-unknown"); ok {
+		t.Fatalf("unknown finishes should not resolve")
+	}
+}
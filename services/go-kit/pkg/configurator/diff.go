@@ -0,0 +1,85 @@
+package configurator
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// FieldChange is a configuration field that differs between two selections.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// OptionChange is an option whose quantity differs. From is zero for added
+// options and To is zero for removed ones.
+type OptionChange struct {
+	ID   string `json:"id"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Diff is the configuration difference between two selections. Request
+// context (configuration ID, locale, channel, acknowledgements) is ignored,
+// matching Fingerprint.
+type Diff struct {
+	Fields      []FieldChange  `json:"fields"`
+	Options     []OptionChange `json:"options"`
+	RoomChanged bool           `json:"roomChanged,omitempty"`
+}
+
+// Empty reports whether the selections describe the same configuration.
+func (d Diff) Empty() bool {
+	return len(d.Fields) == 0 && len(d.Options) == 0 && !d.RoomChanged
+}
+
+// Compare lists what changed from a to b. Fields follow schema order and
+// options are sorted by ID; repeated options are compared by their total
+// quantity.
+func Compare(a, b Selection) Diff {
+	d := Diff{Fields: make([]FieldChange, 0), Options: make([]OptionChange, 0)}
+	field := func(name string, from, to any) {
+		if from != to {
+			d.Fields = append(d.Fields, FieldChange{Field: name, From: from, To: to})
+		}
+	}
+	field("schemaVersion", a.schemaVersion(), b.schemaVersion())
+	field("module", a.Module, b.Module)
+	field("layout", a.Layout, b.Layout)
+	field("finish", a.Finish, b.Finish)
+	field("currency", a.Currency, b.Currency)
+	field("market", NormalizeMarket(a.Market), NormalizeMarket(b.Market))
+	field("dimensions.lengthMm", a.Dimensions.LengthMM, b.Dimensions.LengthMM)
+	field("dimensions.heightMm", a.Dimensions.HeightMM, b.Dimensions.HeightMM)
+
+	from, to := quantities(a.Options), quantities(b.Options)
+	ids := make([]string, 0, len(from)+len(to))
+	for id := range from {
+		ids = append(ids, id)
+	}
+	for id := range to {
+		if _, ok := from[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if from[id] != to[id] {
+			d.Options = append(d.Options, OptionChange{ID: id, From: from[id], To: to[id]})
+		}
+	}
+
+	roomA, _ := json.Marshal(a.Room)
+	roomB, _ := json.Marshal(b.Room)
+	d.RoomChanged = string(roomA) != string(roomB)
+	return d
+}
+
+func quantities(opts []SelectionOption) map[string]int {
+	out := make(map[string]int, len(opts))
+	for _, opt := range opts {
+		out[opt.ID] += opt.Quantity
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package configurator
+
+import "testing"
+
+func TestCompareListsFieldAndOptionChanges(t *testing.T) {
+	a := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Finish:  "matte",
+		Market:  "en-GB",
+		Options: []SelectionOption{{ID: "backsplash", Quantity: 1}, {ID: "drawer-lighting", Quantity: 2}},
+	}
+	b := a
+	b.Layout = "island"
+	b.Market = "GB"
+	b.Channel = "admin"
+	b.Options = []SelectionOption{{ID: "drawer-lighting", Quantity: 4}, {ID: "island-counter", Quantity: 1}}
+	b.Room = &Room{CeilingHeightMM: 2400}
+
+	d := Compare(a, b)
+	if len(d.Fields) != 1 || d.Fields[0] != (FieldChange{Field: "layout", From: "linear", To: "island"}) {
+		t.Fatalf("unexpected field changes %+v", d.Fields)
+	}
+	want := []OptionChange{{ID: "backsplash", From: 1}, {ID: "drawer-lighting", From: 2, To: 4}, {ID: "island-counter", To: 1}}
+	if len(d.Options) != len(want) {
+		t.Fatalf("unexpected option changes %+v", d.Options)
+	}
+	for i := range want {
+		if d.Options[i] != want[i] {
+			t.Fatalf("option change %d: got %+v want %+v", i, d.Options[i], want[i])
+		}
+	}
+	if !d.RoomChanged || d.Empty() {
+		t.Fatalf("expected a room change")
+	}
+	if !Compare(a, a).Empty() {
+		t.Fatalf("a selection should not differ from itself")
+	}
+}
@@ -0,0 +1,100 @@
+package configurator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint is the canonical identity of a configuration: "v<schema>."
+// followed by the hex SHA-256 of the selection's canonical encoding. Equal
+// configurations share a fingerprint regardless of option order or request
+// context (configuration ID, locale, channel, acknowledgements), so caches,
+// CDNs and manufacturing can all key on it. The prefix keeps fingerprints
+// from different schema versions apart.
+func (s Selection) Fingerprint() string {
+	sum := sha256.Sum256(s.canonical())
+	return "v" + strconv.Itoa(s.schemaVersion()) + "." + hex.EncodeToString(sum[:])
+}
+
+// FingerprintVersion returns the schema version a fingerprint was taken
+// under, or false when the value is not a fingerprint.
+func FingerprintVersion(fp string) (int, bool) {
+	prefix, digest, ok := strings.Cut(fp, ".")
+	if !ok || !strings.HasPrefix(prefix, "v") || len(digest) != 2*sha256.Size {
+		return 0, false
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return 0, false
+	}
+	v, err := strconv.Atoi(prefix[1:])
+	if err != nil || v < 1 {
+		return 0, false
+	}
+	return v, true
+}
+
+// canonical encodes the configuration fields in a fixed order. Every string
+// is prefixed with its length and every number is a fixed-width integer, so
+// no field value can be mistaken for a separator or spill into the next
+// field. Options are in canonical order; the room is its JSON form, empty
+// when absent.
+func (s Selection) canonical() []byte {
+	opts := s.SortedOptions()
+
+	var enc canonicalEncoder
+	enc.int(int64(s.schemaVersion()))
+	enc.string(s.Module)
+	enc.string(s.Layout)
+	enc.string(s.Finish)
+	enc.string(s.Currency)
+	enc.int(int64(s.Dimensions.LengthMM))
+	enc.int(int64(s.Dimensions.HeightMM))
+	enc.string(NormalizeMarket(s.Market))
+	enc.int(int64(len(opts)))
+	for _, opt := range opts {
+		enc.string(opt.ID)
+		enc.int(int64(opt.Quantity))
+	}
+	var room []byte
+	if s.Room != nil {
+		room, _ = json.Marshal(s.Room)
+	}
+	enc.bytes(room)
+	return enc.buf
+}
+
+// SortedOptions returns a copy of the options in canonical order: by ID,
+// then quantity. Encodings that must not depend on the order a client sent
+// its options in use this order.
+func (s Selection) SortedOptions() []SelectionOption {
+	opts := append([]SelectionOption(nil), s.Options...)
+	sort.Slice(opts, func(i, j int) bool {
+		if opts[i].ID == opts[j].ID {
+			return opts[i].Quantity < opts[j].Quantity
+		}
+		return opts[i].ID < opts[j].ID
+	})
+	return opts
+}
+
+type canonicalEncoder struct {
+	buf []byte
+}
+
+func (e *canonicalEncoder) int(v int64) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v))
+}
+
+func (e *canonicalEncoder) string(v string) {
+	e.bytes([]byte(v))
+}
+
+func (e *canonicalEncoder) bytes(v []byte) {
+	e.int(int64(len(v)))
+	e.buf = append(e.buf, v...)
+}
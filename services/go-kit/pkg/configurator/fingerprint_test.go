@@ -0,0 +1,37 @@
+package configurator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFingerprintIsVersionedSHA256(t *testing.T) {
+	fp := Selection{Module: "galley", Layout: "linear"}.Fingerprint()
+	if !strings.HasPrefix(fp, "v1.") || len(fp) != len("v1.")+64 {
+		t.Fatalf("unexpected fingerprint %q", fp)
+	}
+	if v, ok := FingerprintVersion(fp); !ok || v != SchemaVersion {
+		t.Fatalf("expected schema version %d, got %d %v", SchemaVersion, v, ok)
+	}
+	for _, bad := range []string{"", "v1.abc", "x1." + strings.Repeat("0", 64), "v0." + strings.Repeat("0", 64), "v1." + strings.Repeat("z", 64)} {
+		if _, ok := FingerprintVersion(bad); ok {
+			t.Fatalf("%q should not parse as a fingerprint", bad)
+		}
+	}
+}
+
+func TestFingerprintResistsDelimiterCollisions(t *testing.T) {
+	// Under the old "id=qty;" encoding these two selections produced the
+	// same input bytes.
+	a := Selection{Module: "galley", Options: []SelectionOption{{ID: "a=1;b", Quantity: 2}}}
+	b := Selection{Module: "galley", Options: []SelectionOption{{ID: "a", Quantity: 1}, {ID: "b", Quantity: 2}}}
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Fatalf("option ids containing separators must not collide")
+	}
+
+	c := Selection{Module: "galley|linear"}
+	d := Selection{Module: "galley", Layout: "linear"}
+	if c.Fingerprint() == d.Fingerprint() {
+		t.Fatalf("field values must not spill into the next field")
+	}
+}
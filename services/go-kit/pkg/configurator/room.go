@@ -0,0 +1,154 @@
+package configurator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Placement tiers. Base and tall units occupy the floor, wall and tall units
+// occupy the upper zone.
+const (
+	TierBase = "base"
+	TierWall = "wall"
+	TierTall = "tall"
+)
+
+// Opening kinds. Doors must stay clear at every tier, windows only above the
+// worktop.
+const (
+	OpeningDoor   = "door"
+	OpeningWindow = "window"
+)
+
+// DefaultBaseDepthMM is the carcass depth assumed for floor units that do not
+// state their own.
+const DefaultBaseDepthMM = 600
+
+// Room describes the space the configuration is installed into.
+type Room struct {
+	CeilingHeightMM int         `json:"ceilingHeightMm"`
+	Walls           []Wall      `json:"walls"`
+	Placements      []Placement `json:"placements,omitempty"`
+	Island          *Island     `json:"island,omitempty"`
+}
+
+// Wall is a straight run measured from its left corner. XMM/YMM place that
+// corner on the floor plan and HeadingDeg gives the run's direction.
+type Wall struct {
+	ID         string    `json:"id"`
+	LengthMM   int       `json:"lengthMm"`
+	XMM        int       `json:"xMm,omitempty"`
+	YMM        int       `json:"yMm,omitempty"`
+	HeadingDeg int       `json:"headingDeg,omitempty"`
+	Openings   []Opening `json:"openings,omitempty"`
+}
+
+// Opening is a door or window cut into a wall.
+type Opening struct {
+	Kind     string `json:"kind"`
+	OffsetMM int    `json:"offsetMm"`
+	WidthMM  int    `json:"widthMm"`
+}
+
+// Placement positions a module unit along a wall.
+type Placement struct {
+	Module   string `json:"module"`
+	WallID   string `json:"wallId"`
+	Tier     string `json:"tier"`
+	OffsetMM int    `json:"offsetMm"`
+	WidthMM  int    `json:"widthMm"`
+	HeightMM int    `json:"heightMm,omitempty"`
+	DepthMM  int    `json:"depthMm,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+// Island is a free-standing footprint with its distance to each facing wall.
+type Island struct {
+	LengthMM   int             `json:"lengthMm"`
+	DepthMM    int             `json:"depthMm"`
+	Clearances []WallClearance `json:"clearances"`
+}
+
+// WallClearance is the distance from an island edge to a wall surface.
+type WallClearance struct {
+	WallID     string `json:"wallId"`
+	DistanceMM int    `json:"distanceMm"`
+}
+
+// Validate checks the room is internally consistent before rules run.
+func (r Room) Validate() error {
+	if r.CeilingHeightMM < 0 {
+		return errors.New("room ceiling height must be >= 0")
+	}
+	walls := make(map[string]struct{}, len(r.Walls))
+	for _, w := range r.Walls {
+		if w.ID == "" {
+			return errors.New("wall id is required")
+		}
+		if _, dup := walls[w.ID]; dup {
+			return fmt.Errorf("wall %s is defined twice", w.ID)
+		}
+		walls[w.ID] = struct{}{}
+		if w.LengthMM <= 0 {
+			return fmt.Errorf("wall %s length must be > 0", w.ID)
+		}
+		for _, o := range w.Openings {
+			if o.Kind != OpeningDoor && o.Kind != OpeningWindow {
+				return fmt.Errorf("wall %s has unknown opening kind %q", w.ID, o.Kind)
+			}
+			if o.WidthMM <= 0 {
+				return fmt.Errorf("wall %s opening width must be > 0", w.ID)
+			}
+		}
+	}
+	for _, p := range r.Placements {
+		switch p.Tier {
+		case TierBase, TierWall, TierTall:
+		default:
+			return fmt.Errorf("placement %s has unknown tier %q", p.Module, p.Tier)
+		}
+		if p.WidthMM <= 0 {
+			return fmt.Errorf("placement %s width must be > 0", p.Module)
+		}
+	}
+	if r.Island != nil && (r.Island.LengthMM <= 0 || r.Island.DepthMM <= 0) {
+		return errors.New("island footprint must be > 0")
+	}
+	return nil
+}
+
+// Wall looks up a wall by ID.
+func (r Room) Wall(id string) (Wall, bool) {
+	if i := r.WallIndex(id); i >= 0 {
+		return r.Walls[i], true
+	}
+	return Wall{}, false
+}
+
+// WallIndex returns the position of the wall with the given ID, or -1.
+func (r Room) WallIndex(id string) int {
+	for i, w := range r.Walls {
+		if w.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// OccupiesFloor reports whether the unit stands on the floor.
+func (p Placement) OccupiesFloor() bool {
+	return p.Tier == TierBase || p.Tier == TierTall
+}
+
+// OccupiesUpper reports whether the unit reaches the upper zone.
+func (p Placement) OccupiesUpper() bool {
+	return p.Tier == TierWall || p.Tier == TierTall
+}
+
+// Depth returns the unit depth, assuming a standard carcass when unset.
+func (p Placement) Depth() int {
+	if p.DepthMM > 0 {
+		return p.DepthMM
+	}
+	return DefaultBaseDepthMM
+}
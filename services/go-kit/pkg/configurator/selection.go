@@ -0,0 +1,120 @@
+// Package configurator is the canonical configuration model shared by the Go
+// services. A Selection means the same thing to rules and pricing: one
+// schema, one validator and one canonical fingerprint, so a schema change happens
+// here once.
+package configurator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersion is the version of the Selection schema this package reads
+// and writes. Selections that omit it are treated as the current version.
+const SchemaVersion = 1
+
+// SelectionOption captures a single option ID + quantity in the configurator.
+type SelectionOption struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+// Dimensions carries the run length and worktop height in millimetres.
+type Dimensions struct {
+	LengthMM int `json:"lengthMm"`
+	HeightMM int `json:"heightMm"`
+}
+
+// Selection describes one kitchen configuration as submitted by the
+// configurator shell. Locale, Channel and Acknowledgements describe the
+// request rather than the configuration and are left out of Fingerprint.
+type Selection struct {
+	SchemaVersion   int               `json:"schemaVersion,omitempty"`
+	ConfigurationID string            `json:"configurationId"`
+	Module          string            `json:"module"`
+	Layout          string            `json:"layout"`
+	Finish          string            `json:"finish"`
+	Currency        string            `json:"currency,omitempty"`
+	Options         []SelectionOption `json:"options"`
+	Dimensions      Dimensions        `json:"dimensions"`
+	Room            *Room             `json:"room,omitempty"`
+	Market          string            `json:"market,omitempty"`
+	Locale          string            `json:"locale,omitempty"`
+	Channel         string            `json:"channel,omitempty"`
+	// Acknowledgements echoes ackTokens for overridable errors the caller
+	// accepts under its channel policy.
+	Acknowledgements []string `json:"acknowledgements,omitempty"`
+}
+
+// Field names a selection field a consumer may require on top of the base
+// schema.
+type Field string
+
+// Fields consumers can require.
+const (
+	FieldLayout   Field = "layout"
+	FieldFinish   Field = "finish"
+	FieldCurrency Field = "currency"
+)
+
+// Validate checks the selection against the schema: a supported version, a
+// module, named options with non-negative quantities and a consistent room.
+// Consumers list the optional fields they cannot work without.
+func (s Selection) Validate(required ...Field) error {
+	if s.SchemaVersion < 0 || s.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("unsupported schema version %d", s.SchemaVersion)
+	}
+	if s.Module == "" {
+		return errors.New("module is required")
+	}
+	for _, f := range required {
+		if s.field(f) == "" {
+			return fmt.Errorf("%s is required", f)
+		}
+	}
+	for _, opt := range s.Options {
+		if opt.ID == "" {
+			return errors.New("option id is required")
+		}
+		if opt.Quantity < 0 {
+			return errors.New("option quantity must be >= 0")
+		}
+	}
+	if s.Dimensions.LengthMM < 0 || s.Dimensions.HeightMM < 0 {
+		return errors.New("dimensions must be >= 0")
+	}
+	if s.Room != nil {
+		return s.Room.Validate()
+	}
+	return nil
+}
+
+func (s Selection) field(f Field) string {
+	switch f {
+	case FieldLayout:
+		return s.Layout
+	case FieldFinish:
+		return s.Finish
+	case FieldCurrency:
+		return s.Currency
+	}
+	return ""
+}
+
+func (s Selection) schemaVersion() int {
+	if s.SchemaVersion == 0 {
+		return SchemaVersion
+	}
+	return s.SchemaVersion
+}
+
+// NormalizeMarket accepts a bare market code ("US") or a locale ("en-US",
+// "en_GB") and returns the lower-case region part.
+func NormalizeMarket(market string) string {
+	market = strings.ToLower(strings.TrimSpace(market))
+	if i := strings.LastIndexAny(market, "-_"); i >= 0 {
+		market = market[i+1:]
+	}
+	return market
+}
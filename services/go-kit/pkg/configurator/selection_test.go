@@ -0,0 +1,59 @@
+package configurator
+
+import "testing"
+
+func TestFingerprintIgnoresOptionOrderAndRequestContext(t *testing.T) {
+	a := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Finish:  "matte",
+		Market:  "en-GB",
+		Locale:  "en",
+		Options: []SelectionOption{{ID: "b", Quantity: 1}, {ID: "a", Quantity: 2}},
+	}
+	b := a
+	b.Options = []SelectionOption{{ID: "a", Quantity: 2}, {ID: "b", Quantity: 1}}
+	b.Market = "GB"
+	b.Locale = "de"
+	b.Channel = "admin"
+	b.ConfigurationID = "other"
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("equivalent selections should share a fingerprint")
+	}
+
+	b.SchemaVersion = SchemaVersion
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("an omitted schema version should mean the current one")
+	}
+	b.Currency = "EUR"
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Fatalf("currency should change the fingerprint")
+	}
+	b.Currency = ""
+	b.Dimensions.LengthMM = 3000
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Fatalf("dimensions should change the fingerprint")
+	}
+}
+
+func TestValidateAppliesSchemaAndRequiredFields(t *testing.T) {
+	sel := Selection{Module: "galley", Options: []SelectionOption{{ID: "a", Quantity: 1}}}
+	if err := sel.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sel.Validate(FieldLayout); err == nil || err.Error() != "layout is required" {
+		t.Fatalf("expected missing layout, got %v", err)
+	}
+
+	cases := map[string]Selection{
+		"schema":   {SchemaVersion: SchemaVersion + 1, Module: "galley"},
+		"module":   {},
+		"quantity": {Module: "galley", Options: []SelectionOption{{ID: "a", Quantity: -1}}},
+		"room":     {Module: "galley", Room: &Room{Walls: []Wall{{ID: "n"}}}},
+	}
+	for name, sel := range cases {
+		if err := sel.Validate(); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package rpc is the shared gRPC bootstrap for the Go services: servers come
+// with tracing, health checking and reflection, and clients with tracing, so
+// every service exposes and calls gRPC the same way.
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is a gRPC server with its health service.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// NewServer builds a server with the tracing interceptor, the standard
+// health service and reflection. Services are registered on the embedded
+// server before Serve and then marked serving with SetServing.
+func NewServer(opts ...grpc.ServerOption) *Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(UnaryServerInterceptor())}, opts...)
+	s := &Server{Server: grpc.NewServer(opts...), health: health.NewServer()}
+	healthpb.RegisterHealthServer(s.Server, s.health)
+	reflection.Register(s.Server)
+	return s
+}
+
+// SetServing reports every registered service, and the server as a whole,
+// as serving or not serving.
+func (s *Server) SetServing(serving bool) {
+	st := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		st = healthpb.HealthCheckResponse_SERVING
+	}
+	s.health.SetServingStatus("", st)
+	for name := range s.GetServiceInfo() {
+		if name == healthpb.Health_ServiceDesc.ServiceName {
+			continue
+		}
+		s.health.SetServingStatus(name, st)
+	}
+}
+
+// Serve marks the server serving and accepts connections on lis until
+// Shutdown.
+func (s *Server) Serve(lis net.Listener) error {
+	s.SetServing(true)
+	return s.Server.Serve(lis)
+}
+
+// Shutdown reports not serving so health checks drain traffic, then stops
+// gracefully. In-flight calls are cancelled if ctx ends first.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+		<-done
+	}
+}
+
+// Dial opens a client connection with the tracing interceptor. Connections
+// are plaintext unless opts supply transport credentials; the services talk
+// over the cluster network like their HTTP clients do.
+func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+	}, opts...)
+	return grpc.NewClient(target, opts...)
+}
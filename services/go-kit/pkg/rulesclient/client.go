@@ -0,0 +1,151 @@
+// Package rulesclient calls the rules service over HTTP. Types mirror the
+// rules-go JSON contract so other services can act on violations and fixes
+// without importing the engine.
+package rulesclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+// ErrUnavailable wraps transport failures and 5xx answers: the rules service
+// could not give a verdict.
+var ErrUnavailable = errors.New("rules service unavailable")
+
+// Error is a 4xx answer: the rules service rejected the request itself.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rules service rejected request (%d): %s", e.Status, e.Message)
+}
+
+// Violation is one rule outcome as reported by the rules service.
+type Violation struct {
+	Rule         string         `json:"rule,omitempty"`
+	Code         string         `json:"code"`
+	Severity     string         `json:"severity"`
+	Message      string         `json:"message"`
+	Paths        []string       `json:"paths,omitempty"`
+	Options      []string       `json:"options,omitempty"`
+	Params       map[string]any `json:"params,omitempty"`
+	Overridable  bool           `json:"overridable,omitempty"`
+	AckToken     string         `json:"ackToken,omitempty"`
+	Acknowledged bool           `json:"acknowledged,omitempty"`
+}
+
+// Change is a single edit to a selection.
+type Change struct {
+	Op       string `json:"op"`
+	Option   string `json:"option,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+	Layout   string `json:"layout,omitempty"`
+	Finish   string `json:"finish,omitempty"`
+}
+
+// Fix is a set of changes that unblocks a selection.
+type Fix struct {
+	Changes []Change `json:"changes"`
+}
+
+// Result is the rules service's validation verdict.
+type Result struct {
+	ConfigurationID string      `json:"configurationId"`
+	Fingerprint     string      `json:"fingerprint"`
+	Violations      []Violation `json:"violations"`
+	Blocking        bool        `json:"blocking"`
+	Packs           []string    `json:"packs"`
+	Locale          string      `json:"locale,omitempty"`
+	Policy          string      `json:"policy,omitempty"`
+	Fixes           []Fix       `json:"fixes,omitempty"`
+}
+
+// Client talks to one rules service base URL. The HTTP client decides
+// timeouts and instrumentation, so callers wrap its transport with otelhttp
+// to keep the rules spans in their trace.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	key     string
+}
+
+// New returns a client for baseURL, e.g. http://rules-go:4110. A nil client
+// uses http.DefaultClient.
+func New(baseURL string, hc *http.Client) *Client {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: hc}
+}
+
+// WithAPIKey returns a copy of the client that authenticates with key. The
+// rules service maps the key to the channel whose policy applies, so a
+// selection's own channel is never sent.
+func (c *Client) WithAPIKey(key string) *Client {
+	out := *c
+	out.key = key
+	return &out
+}
+
+// Validate asks the rules service for violations.
+func (c *Client) Validate(ctx context.Context, sel configurator.Selection) (Result, error) {
+	return c.validate(ctx, sel, "/v1/rules/validate")
+}
+
+// ValidateWithFixes also asks for the smallest change sets that unblock a
+// blocking selection.
+func (c *Client) ValidateWithFixes(ctx context.Context, sel configurator.Selection) (Result, error) {
+	return c.validate(ctx, sel, "/v1/rules/validate?fixes=true")
+}
+
+func (c *Client) validate(ctx context.Context, sel configurator.Selection, path string) (Result, error) {
+	sel.Channel = ""
+	body, err := json.Marshal(sel)
+	if err != nil {
+		return Result{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return Result{}, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	case resp.StatusCode >= 400:
+		var payload struct {
+			Error string `json:"error"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(raw, &payload) != nil || payload.Error == "" {
+			payload.Error = strings.TrimSpace(string(raw))
+		}
+		return Result{}, &Error{Status: resp.StatusCode, Message: payload.Error}
+	}
+
+	var res Result
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return Result{}, fmt.Errorf("%w: decode response: %v", ErrUnavailable, err)
+	}
+	return res, nil
+}
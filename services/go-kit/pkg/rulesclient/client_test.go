@@ -0,0 +1,81 @@
+package rulesclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+func TestValidateWithFixes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/rules/validate" || r.URL.Query().Get("fixes") != "true" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		var sel configurator.Selection
+		_ = json.NewDecoder(r.Body).Decode(&sel)
+		_ = json.NewEncoder(w).Encode(Result{
+			ConfigurationID: sel.ConfigurationID,
+			Blocking:        true,
+			Violations:      []Violation{{Rule: "island-counter-requires-island", Code: "layout.island-counter", Severity: "error"}},
+			Fixes:           []Fix{{Changes: []Change{{Op: "set-layout", Layout: "island"}}}},
+		})
+	}))
+	defer srv.Close()
+
+	res, err := New(srv.URL+"/", nil).ValidateWithFixes(context.Background(), configurator.Selection{ConfigurationID: "cfg", Module: "galley"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Blocking || res.ConfigurationID != "cfg" || len(res.Fixes) != 1 || res.Fixes[0].Changes[0].Layout != "island" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestValidateSendsTheAPIKeyInsteadOfTheChannel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sel configurator.Selection
+		_ = json.NewDecoder(r.Body).Decode(&sel)
+		if got := r.Header.Get("Authorization"); got != "Bearer pricing-key" {
+			t.Errorf("expected the API key, got %q", got)
+		}
+		if sel.Channel != "" || r.Header.Get("X-Channel") != "" {
+			t.Errorf("the channel must not be forwarded, got %q / %q", sel.Channel, r.Header.Get("X-Channel"))
+		}
+		_ = json.NewEncoder(w).Encode(Result{})
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, nil).WithAPIKey("pricing-key")
+	if _, err := client.Validate(context.Background(), configurator.Selection{Module: "galley", Channel: "admin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateClassifiesFailures(t *testing.T) {
+	status := http.StatusBadRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":"layout is required"}`))
+	}))
+
+	var rejected *Error
+	_, err := New(srv.URL, nil).Validate(context.Background(), configurator.Selection{Module: "galley"})
+	if !errors.As(err, &rejected) || rejected.Message != "layout is required" {
+		t.Fatalf("expected a rejection, got %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	if _, err := New(srv.URL, nil).Validate(context.Background(), configurator.Selection{Module: "galley"}); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected unavailable on 5xx, got %v", err)
+	}
+
+	srv.Close()
+	if _, err := New(srv.URL, nil).Validate(context.Background(), configurator.Selection{Module: "galley"}); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected unavailable when unreachable, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Package sharecode turns selections into short, checksummed, URL-safe codes.
+// A code either carries the selection inline or refers to a selection stored
+// server-side; either way the last four bytes are a CRC-32 so mistyped codes
+// are rejected instead of reopening a different configuration.
+//
+// Codes use Crockford's base32 alphabet in lower case. Decoding ignores case
+// and hyphens and reads i, l and o as 1, 1 and 0, so codes survive being read
+// out over the phone.
+package sharecode
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+var (
+	// ErrMalformed is returned for codes that are not valid base32 or do not
+	// decode to a known layout.
+	ErrMalformed = errors.New("malformed share code")
+	// ErrChecksum is returned when a code's checksum does not match.
+	ErrChecksum = errors.New("share code checksum mismatch")
+)
+
+// Code kinds, the first byte of a decoded code. New encodings get new kinds;
+// existing kinds never change so codes already sent stay valid.
+const (
+	kindInline byte = 0x01
+	kindRef    byte = 0x02
+)
+
+// RefSize is the length in bytes of a stored selection's reference.
+const RefSize = 8
+
+// maxInflated bounds how much an inline code may expand to.
+const maxInflated = 64 << 10
+
+var encoding = base32.NewEncoding("0123456789abcdefghjkmnpqrstvwxyz").WithPadding(base32.NoPadding)
+
+// dictionary primes the inline compressor with the identifiers selections
+// are made of. It is part of the inline format: changing it breaks every
+// inline code already issued.
+const dictionary = "USDEURGBPusgbdeen-GBmatteglossstainlesswood-grainlinearl-shapeu-shapeislandgalleyluxecompactpantrywall-run" +
+	"appliance-panelwaterfall-edgeglass-cabinetdrawer-lightingpull-out-pantrycorner-carouseldrawer-organizer" +
+	"range-upgradecooktop-inductionbacksplashisland-counter"
+
+// Payload is what a code decodes to: an inline selection or the reference
+// of a stored one.
+type Payload struct {
+	Selection *configurator.Selection
+	Ref       []byte
+}
+
+// EncodeInline packs the configuration part of a selection into a code.
+// Request context (configuration ID, locale, channel, acknowledgements) is
+// not encoded.
+func EncodeInline(sel configurator.Selection) (string, error) {
+	var raw bytes.Buffer
+	w := &writer{buf: &raw}
+	w.uint(uint64(sel.SchemaVersion))
+	w.string(sel.Module)
+	w.string(sel.Layout)
+	w.string(sel.Finish)
+	w.string(sel.Currency)
+	w.string(sel.Market)
+	w.uint(uint64(sel.Dimensions.LengthMM))
+	w.uint(uint64(sel.Dimensions.HeightMM))
+	w.uint(uint64(len(sel.Options)))
+	for _, opt := range sel.Options {
+		w.string(opt.ID)
+		w.uint(uint64(opt.Quantity))
+	}
+	var room []byte
+	if sel.Room != nil {
+		var err error
+		if room, err = json.Marshal(sel.Room); err != nil {
+			return "", fmt.Errorf("encode room: %w", err)
+		}
+	}
+	w.string(string(room))
+
+	var packed bytes.Buffer
+	packed.WriteByte(kindInline)
+	zw, err := flate.NewWriterDict(&packed, flate.BestCompression, []byte(dictionary))
+	if err != nil {
+		return "", err
+	}
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return seal(packed.Bytes()), nil
+}
+
+// EncodeRef builds a code for a stored selection.
+func EncodeRef(ref []byte) (string, error) {
+	if len(ref) != RefSize {
+		return "", fmt.Errorf("ref must be %d bytes", RefSize)
+	}
+	return seal(append([]byte{kindRef}, ref...)), nil
+}
+
+// Decode verifies a code and unpacks it.
+func Decode(code string) (Payload, error) {
+	data, err := encoding.DecodeString(normalize(code))
+	if err != nil || len(data) < 1+crc32.Size {
+		return Payload{}, ErrMalformed
+	}
+	body, sum := data[:len(data)-crc32.Size], data[len(data)-crc32.Size:]
+	if binary.BigEndian.Uint32(sum) != crc32.ChecksumIEEE(body) {
+		return Payload{}, ErrChecksum
+	}
+
+	switch body[0] {
+	case kindRef:
+		if len(body) != 1+RefSize {
+			return Payload{}, ErrMalformed
+		}
+		return Payload{Ref: append([]byte(nil), body[1:]...)}, nil
+	case kindInline:
+		zr := flate.NewReaderDict(bytes.NewReader(body[1:]), []byte(dictionary))
+		defer zr.Close()
+		raw, err := io.ReadAll(io.LimitReader(zr, maxInflated))
+		if err != nil {
+			return Payload{}, ErrMalformed
+		}
+		sel, err := readSelection(raw)
+		if err != nil {
+			return Payload{}, ErrMalformed
+		}
+		return Payload{Selection: &sel}, nil
+	}
+	return Payload{}, ErrMalformed
+}
+
+func readSelection(raw []byte) (configurator.Selection, error) {
+	r := &reader{buf: bytes.NewReader(raw)}
+	sel := configurator.Selection{
+		SchemaVersion: r.int(),
+		Module:        r.string(),
+		Layout:        r.string(),
+		Finish:        r.string(),
+		Currency:      r.string(),
+		Market:        r.string(),
+		Dimensions:    configurator.Dimensions{LengthMM: r.int(), HeightMM: r.int()},
+	}
+	n := r.int()
+	if r.err == nil && n > len(raw) {
+		return sel, ErrMalformed
+	}
+	sel.Options = make([]configurator.SelectionOption, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		sel.Options = append(sel.Options, configurator.SelectionOption{ID: r.string(), Quantity: r.int()})
+	}
+	if room := r.string(); room != "" {
+		sel.Room = &configurator.Room{}
+		if err := json.Unmarshal([]byte(room), sel.Room); err != nil {
+			return sel, err
+		}
+	}
+	if r.err == nil && r.buf.Len() != 0 {
+		return sel, ErrMalformed
+	}
+	return sel, r.err
+}
+
+// seal appends the checksum and encodes.
+func seal(body []byte) string {
+	return encoding.EncodeToString(binary.BigEndian.AppendUint32(body, crc32.ChecksumIEEE(body)))
+}
+
+func normalize(code string) string {
+	return strings.NewReplacer("-", "", "i", "1", "l", "1", "o", "0").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+type writer struct {
+	buf *bytes.Buffer
+}
+
+func (w *writer) uint(v uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+func (w *writer) string(v string) {
+	w.uint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+type reader struct {
+	buf *bytes.Reader
+	err error
+}
+
+func (r *reader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(r.buf)
+	if err != nil || v > 1<<31 {
+		r.err = ErrMalformed
+		return 0
+	}
+	return int(v)
+}
+
+func (r *reader) string() string {
+	n := r.int()
+	if r.err != nil {
+		return ""
+	}
+	if n > r.buf.Len() {
+		r.err = ErrMalformed
+		return ""
+	}
+	b := make([]byte, n)
+	_, _ = io.ReadFull(r.buf, b)
+	return string(b)
+}
@@ -0,0 +1,77 @@
+package sharecode
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+func TestInlineCodeRoundTrips(t *testing.T) {
+	sel := configurator.Selection{
+		ConfigurationID: "cfg-1",
+		Module:          "galley",
+		Layout:          "island",
+		Finish:          "gloss",
+		Currency:        "USD",
+		Market:          "en-GB",
+		Channel:         "retail-web",
+		Dimensions:      configurator.Dimensions{LengthMM: 4200},
+		Options:         []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}, {ID: "drawer-lighting", Quantity: 4}},
+	}
+	code, err := EncodeInline(sel)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if len(code) > 64 || strings.Trim(code, "0123456789abcdefghjkmnpqrstvwxyz") != "" {
+		t.Fatalf("expected a short base32 code, got %q (%d chars)", code, len(code))
+	}
+
+	got, err := Decode(strings.ToUpper(code))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := sel
+	want.ConfigurationID, want.Channel = "", ""
+	if got.Selection == nil || !reflect.DeepEqual(*got.Selection, want) {
+		t.Fatalf("round trip mismatch\n got %+v\nwant %+v", got.Selection, want)
+	}
+	if got.Selection.Fingerprint() != sel.Fingerprint() {
+		t.Fatalf("decoded selection should keep its fingerprint")
+	}
+}
+
+func TestRefCodeRoundTrips(t *testing.T) {
+	ref := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	code, err := EncodeRef(ref)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := Decode(code[:5] + "-" + code[5:])
+	if err != nil || got.Selection != nil || !reflect.DeepEqual(got.Ref, ref) {
+		t.Fatalf("unexpected payload %+v %v", got, err)
+	}
+	if _, err := EncodeRef([]byte{1}); err == nil {
+		t.Fatalf("short refs should be rejected")
+	}
+}
+
+func TestDecodeRejectsTyposAndGarbage(t *testing.T) {
+	code, _ := EncodeRef([]byte("abcdefgh"))
+	typo := []byte(code)
+	if typo[3] == 'a' {
+		typo[3] = 'b'
+	} else {
+		typo[3] = 'a'
+	}
+	if _, err := Decode(string(typo)); !errors.Is(err, ErrChecksum) {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	for _, bad := range []string{"", "u", "!!!!", seal([]byte{0x7f, 1, 2})} {
+		if _, err := Decode(bad); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("%q: expected malformed, got %v", bad, err)
+		}
+	}
+}
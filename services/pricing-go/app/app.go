@@ -2,30 +2,50 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"os/signal"
 	"syscall"
 	"time"
 
+	pricingv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/pricing/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/bom"
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
 	gologger "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/logger"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rpc"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/telemetry"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/catalogimport"
 	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/config"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/configstore"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/evaluate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/grpcapi"
 	transport "github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/http"
 	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quote"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quotepdf"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/share"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // App wires transport + domain services and exposes a Run helper.
 type App struct {
 	server            *http.Server
+	grpcServer        *rpc.Server
+	grpcAddr          string
 	shutdownTimeout   time.Duration
 	log               zerolog.Logger
 	telemetryShutdown func(context.Context)
 }
 
 // New builds the pricing service using env configuration.
-func New() *App {
+func New() (*App, error) {
 	cfg := config.Load()
 	log := gologger.New("pricing-go")
 
@@ -54,9 +74,92 @@ func New() *App {
 	}
 
 	matrix := pricing.NewMatrix(defaultModulePricing(), defaultOptionPricing())
+	if err := matrix.CheckCatalog(catalog.Default()); err != nil {
+		return nil, err
+	}
 	svc := pricing.NewService(matrix, cacheLayer, cfg.CacheTTL)
 
-	handler := transport.NewHTTPHandler(log, svc)
+	// Propagate the trace into rules-go so both engines land in one trace.
+	// rules-go picks the channel policy from our API key, not from callers.
+	rules := rulesclient.New(cfg.RulesURL, &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   cfg.RulesTimeout,
+	}).WithAPIKey(cfg.RulesAPIKey)
+	if cfg.RulesAPIKey == "" {
+		log.Warn().Msg("RULES_API_KEY unset, rules checks use the rules service's default channel")
+	}
+	policy := gate.Policy{Mode: gate.ParseMode(cfg.RulesMode), FailClosed: make(map[gate.Operation]bool)}
+	for _, op := range cfg.RulesFailClosed {
+		policy.FailClosed[gate.Operation(op)] = true
+	}
+	var configs configstore.Backend
+	switch cfg.ConfigStore {
+	case "memory":
+		configs = configstore.NewMemoryBackend()
+	case "file":
+		// Saved configurations must not silently land in memory and vanish
+		// on restart, so an unusable directory stops the service.
+		fileStore, err := configstore.NewFileBackend(cfg.ConfigStoreDir)
+		if err != nil {
+			return nil, fmt.Errorf("CONFIG_STORE_DIR: %w", err)
+		}
+		configs = fileStore
+	default:
+		return nil, fmt.Errorf("CONFIG_STORE: unknown backend %q", cfg.ConfigStore)
+	}
+	authors, err := auth.ParseKeys(cfg.ConfigAuthorKeys)
+	if err != nil {
+		return nil, fmt.Errorf("CONFIG_AUTHOR_KEYS: %w", err)
+	}
+	if authors.Len() == 0 {
+		log.Info().Msg("CONFIG_AUTHOR_KEYS unset, saved configuration API disabled")
+	}
+
+	// A configured rules file that does not load is a deployment error;
+	// expanding with the embedded rules instead would ship wrong parts.
+	bomRules := bom.Default()
+	if cfg.BOMRulesFile != "" {
+		if bomRules, err = bom.LoadFile(cfg.BOMRulesFile); err != nil {
+			return nil, fmt.Errorf("BOM_RULES_FILE: %w", err)
+		}
+	}
+
+	// Documents that silently dropped tax or the configured branding would
+	// go out to customers wrong, so both stop the service.
+	taxes, err := pricing.ParseTaxRates(cfg.QuoteTaxRates)
+	if err != nil {
+		return nil, fmt.Errorf("QUOTE_TAX_RATES: %w", err)
+	}
+	documents := quotepdf.Config{Brand: cfg.QuoteBrand, TaxRates: taxes}
+	if cfg.QuoteTemplateFile != "" {
+		if documents.Template, err = quotepdf.LoadTemplate(cfg.QuoteTemplateFile); err != nil {
+			return nil, fmt.Errorf("QUOTE_TEMPLATE_FILE: %w", err)
+		}
+	}
+	renderer, err := quotepdf.New(documents)
+	if err != nil {
+		return nil, fmt.Errorf("QUOTE_TEMPLATE_FILE: %w", err)
+	}
+
+	rulesGate := gate.New(rules, policy)
+	evaluator := evaluate.NewEvaluator(rules, svc)
+	shares := share.NewService(evaluator, cacheLayer, share.Config{
+		TTL:       cfg.ShareTTL,
+		MaxInline: cfg.ShareMaxInline,
+		BaseURL:   cfg.ShareBaseURL,
+	})
+	handler := transport.NewHTTPHandler(log, svc, transport.Options{
+		Evaluator:      evaluator,
+		Gate:           rulesGate,
+		Quotes:         quote.NewService(svc, rulesGate, cacheLayer, shares, cfg.QuoteValidity),
+		Catalog:        catalogimport.NewResolver(catalog.DefaultAliases(), matrix),
+		Shares:         shares,
+		Configurations: configstore.NewStore(configs),
+		AuthorKeys:     authors,
+		BOM:            bomRules,
+		Documents:      renderer,
+		Taxes:          taxes,
+	})
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
@@ -66,12 +169,17 @@ func New() *App {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	grpcServer := rpc.NewServer()
+	pricingv1.RegisterPricingServiceServer(grpcServer, grpcapi.NewServer(log, svc, rulesGate))
+
 	return &App{
 		server:            srv,
+		grpcServer:        grpcServer,
+		grpcAddr:          ":" + cfg.GRPCPort,
 		shutdownTimeout:   cfg.ShutdownTimeout,
 		log:               log,
 		telemetryShutdown: telemetryShutdown,
-	}
+	}, nil
 }
 
 // Run listens for OS signals and blocks until shutdown completes.
@@ -86,19 +194,38 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
-	errCh := make(chan error, 1)
+	lis, err := net.Listen("tcp", a.grpcAddr)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 2)
 	go func() {
 		a.log.Info().Str("addr", a.server.Addr).Msg("pricing service listening")
 		errCh <- a.server.ListenAndServe()
 	}()
+	go func() {
+		a.log.Info().Str("addr", a.grpcAddr).Msg("pricing gRPC service listening")
+		errCh <- a.grpcServer.Serve(lis)
+	}()
 
+	var runErr error
 	select {
 	case <-ctx.Done():
-		shCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
-		defer cancel()
 		a.log.Info().Msg("shutting down pricing service")
-		return a.server.Shutdown(shCtx)
-	case err := <-errCh:
-		return err
+	case runErr = <-errCh:
+	}
+
+	// Both servers share one lifecycle: whichever stops first takes the
+	// other down with it.
+	shCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+	a.grpcServer.Shutdown(shCtx)
+	if err := a.server.Shutdown(shCtx); err != nil && runErr == nil {
+		runErr = err
+	}
+	if errors.Is(runErr, http.ErrServerClosed) {
+		return nil
 	}
+	return runErr
 }
@@ -23,5 +23,6 @@ func defaultOptionPricing() map[string]float64 {
 		"range-upgrade":     2100,
 		"cooktop-induction": 980,
 		"backsplash":        300,
+		"island-counter":    1650,
 	}
 }
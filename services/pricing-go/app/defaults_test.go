@@ -0,0 +1,15 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+func TestDefaultOptionPricesCoverTheCatalog(t *testing.T) {
+	matrix := pricing.NewMatrix(defaultModulePricing(), defaultOptionPricing())
+	if err := matrix.CheckCatalog(catalog.Default()); err != nil {
+		t.Fatal(err)
+	}
+}
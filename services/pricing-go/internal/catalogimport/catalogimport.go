@@ -0,0 +1,168 @@
+// Package catalogimport joins the gateway catalog export with the pricing
+// price book. Gateway IDs and SKUs are resolved through the shared alias
+// table; the report lists what joined, catalog items without a price and
+// price entries no catalog item points at.
+package catalogimport
+
+import (
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+// Kinds of price book entries.
+const (
+	KindModule = "module"
+	KindFinish = "finish"
+)
+
+// Reasons a catalog item has no price.
+const (
+	// ReasonNoAlias means the alias table does not know the gateway ID.
+	ReasonNoAlias = "no-alias"
+	// ReasonNotInPriceBook means the alias points at a key the price book
+	// does not carry.
+	ReasonNotInPriceBook = "not-in-price-book"
+)
+
+// Item is one gateway catalog item and the price book key it resolved to.
+// Finishes carry the module they were exported under. GatewayPrice is the
+// module's basePrice or the finish's costDelta as the gateway sees it.
+type Item struct {
+	Kind         string  `json:"kind"`
+	ID           string  `json:"id"`
+	SKU          string  `json:"sku,omitempty"`
+	Name         string  `json:"name"`
+	Module       string  `json:"module,omitempty"`
+	Key          string  `json:"key,omitempty"`
+	GatewayPrice float64 `json:"gatewayPrice"`
+	Reason       string  `json:"reason,omitempty"`
+}
+
+// Entry is a price book entry no catalog item resolves to.
+type Entry struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+}
+
+// Report is the outcome of joining one export with the price book.
+type Report struct {
+	Mapped       []Item  `json:"mapped"`
+	Unpriced     []Item  `json:"unpriced"`
+	Uncatalogued []Entry `json:"uncatalogued"`
+}
+
+// Resolver maps gateway IDs and SKUs onto price book keys.
+type Resolver struct {
+	aliases catalog.Aliases
+	matrix  *pricing.Matrix
+}
+
+// NewResolver returns a resolver over the alias table and price book.
+func NewResolver(aliases catalog.Aliases, matrix *pricing.Matrix) *Resolver {
+	return &Resolver{aliases: aliases, matrix: matrix}
+}
+
+// Resolve looks up a gateway module ID, SKU or finish ID. Keys the price
+// book already uses resolve to themselves. The item's Reason is set when it
+// resolves to nothing priced.
+func (r *Resolver) Resolve(id string) (Item, bool) {
+	if key, ok := r.aliases.Module(id); ok {
+		return r.module(Item{Kind: KindModule, ID: id}, key), true
+	}
+	if key, ok := r.aliases.Finish(id); ok {
+		return r.finish(Item{Kind: KindFinish, ID: id}, key), true
+	}
+	if r.matrix.HasModule(id) {
+		return Item{Kind: KindModule, ID: id, Key: id}, true
+	}
+	if r.matrix.HasFinish(id) {
+		return Item{Kind: KindFinish, ID: id, Key: id}, true
+	}
+	return Item{ID: id, Reason: ReasonNoAlias}, false
+}
+
+// Canonical rewrites gateway module and finish IDs in a selection to their
+// price book keys, so selections built from the gateway catalog price like
+// native ones. Unknown IDs and price book keys are left as they are. A module
+// SKU resolves like its ID.
+func (r *Resolver) Canonical(sel pricing.Selection) pricing.Selection {
+	if key, ok := r.aliases.Module(sel.Module); ok {
+		sel.Module = key
+	}
+	if key, ok := r.aliases.Finish(sel.Finish); ok {
+		sel.Finish = key
+	}
+	return sel
+}
+
+// Import joins an export with the price book. Modules resolve by ID first
+// and SKU second. Items keep export order; uncatalogued entries are sorted
+// modules first, then finishes.
+func (r *Resolver) Import(modules []catalog.GatewayModule) Report {
+	report := Report{
+		Mapped:       make([]Item, 0),
+		Unpriced:     make([]Item, 0),
+		Uncatalogued: make([]Entry, 0),
+	}
+	seen := map[string]map[string]struct{}{KindModule: {}, KindFinish: {}}
+	add := func(item Item) {
+		if item.Reason != "" {
+			report.Unpriced = append(report.Unpriced, item)
+			return
+		}
+		report.Mapped = append(report.Mapped, item)
+		seen[item.Kind][item.Key] = struct{}{}
+	}
+
+	for _, m := range modules {
+		item := Item{Kind: KindModule, ID: m.ID, SKU: m.SKU, Name: m.Name, GatewayPrice: m.BasePrice}
+		key, ok := r.aliases.Module(m.ID)
+		if !ok && m.SKU != "" {
+			key, ok = r.aliases.Module(m.SKU)
+		}
+		if ok {
+			item = r.module(item, key)
+		} else {
+			item.Reason = ReasonNoAlias
+		}
+		add(item)
+
+		for _, f := range m.Finishes {
+			item := Item{Kind: KindFinish, ID: f.ID, Name: f.Label, Module: m.ID, GatewayPrice: f.CostDelta}
+			if key, ok := r.aliases.Finish(f.ID); ok {
+				item = r.finish(item, key)
+			} else {
+				item.Reason = ReasonNoAlias
+			}
+			add(item)
+		}
+	}
+
+	for _, key := range r.matrix.Modules() {
+		if _, ok := seen[KindModule][key]; !ok {
+			report.Uncatalogued = append(report.Uncatalogued, Entry{Kind: KindModule, Key: key})
+		}
+	}
+	for _, key := range r.matrix.Finishes() {
+		if _, ok := seen[KindFinish][key]; !ok {
+			report.Uncatalogued = append(report.Uncatalogued, Entry{Kind: KindFinish, Key: key})
+		}
+	}
+	return report
+}
+
+func (r *Resolver) module(item Item, key string) Item {
+	item.Key = key
+	if !r.matrix.HasModule(key) {
+		item.Reason = ReasonNotInPriceBook
+	}
+	return item
+}
+
+func (r *Resolver) finish(item Item, key string) Item {
+	item.Key = key
+	if !r.matrix.HasFinish(key) {
+		item.Reason = ReasonNotInPriceBook
+	}
+	return item
+}
@@ -0,0 +1,81 @@
+package catalogimport
+
+import (
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+func newResolver() *Resolver {
+	aliases := catalog.Aliases{
+		Modules:  map[string]string{"PVZ-GAL-S": "galley", "mod-island-performance": "island"},
+		Finishes: map[string]string{"finish-graphite": "matte", "finish-terra": "walnut"},
+	}
+	return NewResolver(aliases, pricing.NewMatrix(map[string]float64{"galley": 5200, "pantry": 3100}, nil))
+}
+
+func TestImportReportsGapsBothWays(t *testing.T) {
+	report := newResolver().Import([]catalog.GatewayModule{
+		{ID: "mod-galley-s", SKU: "PVZ-GAL-S", Name: "Galley S", BasePrice: 52000, Finishes: []catalog.GatewayFinish{
+			{ID: "finish-graphite", Label: "Graphite Matte", CostDelta: 2400},
+			{ID: "finish-polar", Label: "Polar Satin", CostDelta: 3100},
+		}},
+		{ID: "mod-island-performance", Name: "Island Performance", Finishes: []catalog.GatewayFinish{
+			{ID: "finish-terra", Label: "Terra Walnut"},
+		}},
+	})
+
+	if len(report.Mapped) != 2 || report.Mapped[0].Key != "galley" || report.Mapped[1].Key != "matte" {
+		t.Fatalf("expected the galley to join by SKU and graphite to matte, got %+v", report.Mapped)
+	}
+	want := map[string]string{
+		"finish-polar":           ReasonNoAlias,
+		"mod-island-performance": ReasonNotInPriceBook,
+		"finish-terra":           ReasonNotInPriceBook,
+	}
+	if len(report.Unpriced) != len(want) {
+		t.Fatalf("unexpected unpriced items %+v", report.Unpriced)
+	}
+	for _, item := range report.Unpriced {
+		if want[item.ID] != item.Reason {
+			t.Fatalf("item %s: expected reason %q, got %q", item.ID, want[item.ID], item.Reason)
+		}
+	}
+
+	var modules, finishes []string
+	for _, e := range report.Uncatalogued {
+		if e.Kind == KindModule {
+			modules = append(modules, e.Key)
+		} else {
+			finishes = append(finishes, e.Key)
+		}
+	}
+	if len(modules) != 1 || modules[0] != "pantry" || len(finishes) != 3 {
+		t.Fatalf("expected pantry and the unmapped finishes, got %+v", report.Uncatalogued)
+	}
+}
+
+func TestResolveAcceptsAliasesAndBookKeys(t *testing.T) {
+	r := newResolver()
+	if item, ok := r.Resolve("PVZ-GAL-S"); !ok || item.Kind != KindModule || item.Key != "galley" || item.Reason != "" {
+		t.Fatalf("unexpected SKU resolution %+v", item)
+	}
+	if item, ok := r.Resolve("gloss"); !ok || item.Kind != KindFinish || item.Key != "gloss" {
+		t.Fatalf("book keys should resolve to themselves, got %+v", item)
+	}
+	if item, ok := r.Resolve("mod-unknown"); ok || item.Reason != ReasonNoAlias {
+		t.Fatalf("unknown ids should not resolve, got %+v", item)
+	}
+}
+
+func TestCanonicalRewritesGatewayIDs(t *testing.T) {
+	r := newResolver()
+	got := r.Canonical(pricing.Selection{Module: "PVZ-GAL-S", Finish: "finish-graphite", Layout: "linear"})
+	if got.Module != "galley" || got.Finish != "matte" || got.Layout != "linear" {
+		t.Fatalf("expected price book keys, got %+v", got)
+	}
+	if got := r.Canonical(pricing.Selection{Module: "pantry", Finish: "gloss"}); got.Module != "pantry" || got.Finish != "gloss" {
+		t.Fatalf("expected price book keys to stay, got %+v", got)
+	}
+}
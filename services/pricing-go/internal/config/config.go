@@ -3,12 +3,14 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config captures runtime configuration for the pricing service.
 type Config struct {
 	HTTPPort          string
+	GRPCPort          string
 	RedisAddr         string
 	RedisPassword     string
 	RedisDB           int
@@ -18,6 +20,22 @@ type Config struct {
 	TelemetryInsecure bool
 	ServiceName       string
 	Environment       string
+	RulesURL          string
+	RulesTimeout      time.Duration
+	RulesMode         string
+	RulesFailClosed   []string
+	RulesAPIKey       string
+	QuoteValidity     time.Duration
+	ShareTTL          time.Duration
+	ShareMaxInline    int
+	ShareBaseURL      string
+	ConfigStore       string
+	ConfigStoreDir    string
+	ConfigAuthorKeys  string
+	BOMRulesFile      string
+	QuoteBrand        string
+	QuoteTemplateFile string
+	QuoteTaxRates     []string
 }
 
 // Load builds Config from env vars with deterministic defaults so the service
@@ -25,6 +43,7 @@ type Config struct {
 func Load() Config {
 	cfg := Config{
 		HTTPPort:          valueOrDefault("HTTP_PORT", "4108"),
+		GRPCPort:          valueOrDefault("GRPC_PORT", "4109"),
 		RedisAddr:         os.Getenv("REDIS_ADDR"),
 		RedisPassword:     os.Getenv("REDIS_PASSWORD"),
 		CacheTTL:          durationOrDefault("CACHE_TTL", time.Minute*5),
@@ -33,6 +52,22 @@ func Load() Config {
 		TelemetryInsecure: boolOrDefault("OTEL_EXPORTER_OTLP_INSECURE", true),
 		ServiceName:       valueOrDefault("OTEL_SERVICE_NAME", "pricing-go"),
 		Environment:       valueOrDefault("ENVIRONMENT", "local"),
+		RulesURL:          valueOrDefault("RULES_URL", "http://localhost:4110"),
+		RulesTimeout:      durationOrDefault("RULES_TIMEOUT", time.Second),
+		RulesMode:         valueOrDefault("RULES_MODE", "enforce"),
+		RulesFailClosed:   listOrDefault("RULES_FAIL_CLOSED", []string{"quote"}),
+		RulesAPIKey:       os.Getenv("RULES_API_KEY"),
+		QuoteValidity:     durationOrDefault("QUOTE_VALIDITY", 30*24*time.Hour),
+		ShareTTL:          durationOrDefault("SHARE_TTL", 90*24*time.Hour),
+		ShareMaxInline:    intOrDefault("SHARE_MAX_INLINE", 64),
+		ShareBaseURL:      os.Getenv("SHARE_BASE_URL"),
+		ConfigStore:       valueOrDefault("CONFIG_STORE", "memory"),
+		ConfigStoreDir:    valueOrDefault("CONFIG_STORE_DIR", "data/configurations"),
+		ConfigAuthorKeys:  os.Getenv("CONFIG_AUTHOR_KEYS"),
+		BOMRulesFile:      os.Getenv("BOM_RULES_FILE"),
+		QuoteBrand:        valueOrDefault("QUOTE_BRAND", "Parviz Kitchens"),
+		QuoteTemplateFile: os.Getenv("QUOTE_TEMPLATE_FILE"),
+		QuoteTaxRates:     listOrDefault("QUOTE_TAX_RATES", []string{"gb=0.20", "de=0.19", "fr=0.20"}),
 	}
 
 	if v := os.Getenv("REDIS_DB"); v != "" {
@@ -51,6 +86,29 @@ func valueOrDefault(key, fallback string) string {
 	return fallback
 }
 
+func listOrDefault(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	out := make([]string, 0)
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func intOrDefault(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
 func durationOrDefault(key string, fallback time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if parsed, err := time.ParseDuration(v); err == nil {
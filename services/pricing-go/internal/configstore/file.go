@@ -0,0 +1,102 @@
+package configstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9-]{1,64}$`)
+
+// FileBackend keeps each configuration's history in one JSON file under a
+// directory. Writes go to a temporary file that is synced and renamed over
+// the old one, so a crash leaves either the previous or the new history,
+// never a torn one. A single process should own the directory.
+type FileBackend struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileBackend returns a backend writing to dir, creating it if needed.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create configuration store: %w", err)
+	}
+	return &FileBackend{dir: dir}, nil
+}
+
+// Load implements Backend.
+func (b *FileBackend) Load(_ context.Context, id string) ([]Revision, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.read(id)
+}
+
+// Append implements Backend.
+func (b *FileBackend) Append(_ context.Context, id string, rev Revision) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("%w: id %q", ErrInvalid, id)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	history, err := b.read(id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if rev.Number != len(history)+1 {
+		return ErrConflict
+	}
+	payload, err := json.Marshal(append(history, rev))
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(b.dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(payload); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.path(id))
+}
+
+func (b *FileBackend) read(id string) ([]Revision, error) {
+	if !validID.MatchString(id) {
+		return nil, ErrNotFound
+	}
+	raw, err := os.ReadFile(b.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var history []Revision
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("decode configuration %s: %w", id, err)
+	}
+	if len(history) == 0 {
+		return nil, ErrNotFound
+	}
+	return history, nil
+}
+
+func (b *FileBackend) path(id string) string {
+	return filepath.Join(b.dir, id+".json")
+}
@@ -0,0 +1,40 @@
+package configstore
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBackend keeps revisions in process memory. It is meant for tests and
+// local development; everything is lost on restart.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	configs map[string][]Revision
+}
+
+// NewMemoryBackend returns an empty in-memory backend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{configs: make(map[string][]Revision)}
+}
+
+// Load implements Backend.
+func (b *MemoryBackend) Load(_ context.Context, id string) ([]Revision, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	history, ok := b.configs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]Revision(nil), history...), nil
+}
+
+// Append implements Backend.
+func (b *MemoryBackend) Append(_ context.Context, id string, rev Revision) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if rev.Number != len(b.configs[id])+1 {
+		return ErrConflict
+	}
+	b.configs[id] = append(b.configs[id], rev)
+	return nil
+}
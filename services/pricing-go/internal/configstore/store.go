@@ -0,0 +1,179 @@
+// Package configstore persists saved configurations. Every save appends an
+// immutable revision recording who saved what and when; the latest revision
+// is the configuration. Backends only ever append, so history cannot be
+// rewritten, and restoring an old revision appends a copy of it.
+package configstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+var (
+	// ErrNotFound is returned for unknown configurations and revisions.
+	ErrNotFound = errors.New("configuration not found")
+	// ErrConflict is returned when a save is based on a stale revision.
+	ErrConflict = errors.New("configuration revision conflict")
+	// ErrInvalid wraps rejected selections and requests.
+	ErrInvalid = errors.New("invalid configuration")
+)
+
+// Revision is one immutable save. RestoredFrom names the revision a restore
+// copied.
+type Revision struct {
+	Number       int                    `json:"revision"`
+	Author       string                 `json:"author"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	Selection    configurator.Selection `json:"selection"`
+	Fingerprint  string                 `json:"fingerprint"`
+	RestoredFrom int                    `json:"restoredFrom,omitempty"`
+}
+
+// Configuration is a saved configuration at its latest revision.
+type Configuration struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Revision
+}
+
+// Backend stores revisions. Append must fail with ErrConflict unless
+// rev.Number is exactly one past the last stored revision, which keeps
+// concurrent writers from overwriting each other. Load returns revisions
+// oldest first, or ErrNotFound.
+type Backend interface {
+	Load(ctx context.Context, id string) ([]Revision, error)
+	Append(ctx context.Context, id string, rev Revision) error
+}
+
+// Store implements configuration operations on a backend.
+type Store struct {
+	backend Backend
+	now     func() time.Time
+}
+
+// NewStore returns a store over the backend.
+func NewStore(backend Backend) *Store {
+	return &Store{backend: backend, now: time.Now}
+}
+
+// Create saves a new configuration as revision 1. The selection's
+// configuration ID is set to the new configuration's ID.
+func (s *Store) Create(ctx context.Context, author string, sel configurator.Selection) (Configuration, error) {
+	id := uuid.NewString()
+	rev, err := s.revision(id, 1, author, sel)
+	if err != nil {
+		return Configuration{}, err
+	}
+	if err := s.backend.Append(ctx, id, rev); err != nil {
+		return Configuration{}, err
+	}
+	return Configuration{ID: id, CreatedAt: rev.CreatedAt, Revision: rev}, nil
+}
+
+// Save appends a revision. A base of zero skips the staleness check;
+// otherwise base must be the current revision.
+func (s *Store) Save(ctx context.Context, id, author string, base int, sel configurator.Selection) (Configuration, error) {
+	history, err := s.backend.Load(ctx, id)
+	if err != nil {
+		return Configuration{}, err
+	}
+	if base != 0 && base != len(history) {
+		return Configuration{}, ErrConflict
+	}
+	rev, err := s.revision(id, len(history)+1, author, sel)
+	if err != nil {
+		return Configuration{}, err
+	}
+	return s.append(ctx, id, history, rev)
+}
+
+// Get returns the configuration at its latest revision.
+func (s *Store) Get(ctx context.Context, id string) (Configuration, error) {
+	history, err := s.backend.Load(ctx, id)
+	if err != nil {
+		return Configuration{}, err
+	}
+	return latest(id, history), nil
+}
+
+// History returns every revision, oldest first.
+func (s *Store) History(ctx context.Context, id string) ([]Revision, error) {
+	return s.backend.Load(ctx, id)
+}
+
+// Revision returns one revision.
+func (s *Store) Revision(ctx context.Context, id string, n int) (Revision, error) {
+	history, err := s.backend.Load(ctx, id)
+	if err != nil {
+		return Revision{}, err
+	}
+	if n < 1 || n > len(history) {
+		return Revision{}, fmt.Errorf("%w: revision %d", ErrNotFound, n)
+	}
+	return history[n-1], nil
+}
+
+// Diff compares two revisions of a configuration.
+func (s *Store) Diff(ctx context.Context, id string, from, to int) (configurator.Diff, error) {
+	a, err := s.Revision(ctx, id, from)
+	if err != nil {
+		return configurator.Diff{}, err
+	}
+	b, err := s.Revision(ctx, id, to)
+	if err != nil {
+		return configurator.Diff{}, err
+	}
+	return configurator.Compare(a.Selection, b.Selection), nil
+}
+
+// Restore appends a copy of revision n as the new latest revision.
+func (s *Store) Restore(ctx context.Context, id, author string, n int) (Configuration, error) {
+	history, err := s.backend.Load(ctx, id)
+	if err != nil {
+		return Configuration{}, err
+	}
+	if n < 1 || n > len(history) {
+		return Configuration{}, fmt.Errorf("%w: revision %d", ErrNotFound, n)
+	}
+	rev, err := s.revision(id, len(history)+1, author, history[n-1].Selection)
+	if err != nil {
+		return Configuration{}, err
+	}
+	rev.RestoredFrom = n
+	return s.append(ctx, id, history, rev)
+}
+
+func (s *Store) revision(id string, n int, author string, sel configurator.Selection) (Revision, error) {
+	if author == "" {
+		return Revision{}, fmt.Errorf("%w: author is required", ErrInvalid)
+	}
+	if err := sel.Validate(); err != nil {
+		return Revision{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	// Request context belongs to whoever loads the configuration next.
+	sel.ConfigurationID = id
+	sel.Locale, sel.Channel, sel.Acknowledgements = "", "", nil
+	return Revision{
+		Number:      n,
+		Author:      author,
+		CreatedAt:   s.now().UTC(),
+		Selection:   sel,
+		Fingerprint: sel.Fingerprint(),
+	}, nil
+}
+
+func (s *Store) append(ctx context.Context, id string, history []Revision, rev Revision) (Configuration, error) {
+	if err := s.backend.Append(ctx, id, rev); err != nil {
+		return Configuration{}, err
+	}
+	return latest(id, append(history, rev)), nil
+}
+
+func latest(id string, history []Revision) Configuration {
+	return Configuration{ID: id, CreatedAt: history[0].CreatedAt, Revision: history[len(history)-1]}
+}
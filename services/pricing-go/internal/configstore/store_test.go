@@ -0,0 +1,111 @@
+package configstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+func backends(t *testing.T) map[string]Backend {
+	file, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("file backend: %v", err)
+	}
+	return map[string]Backend{"memory": NewMemoryBackend(), "file": file}
+}
+
+func TestRevisionsDiffAndRestore(t *testing.T) {
+	ctx := context.Background()
+	for name, backend := range backends(t) {
+		store := NewStore(backend)
+		clock := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { clock = clock.Add(time.Minute); return clock }
+
+		base := configurator.Selection{Module: "galley", Layout: "linear", Finish: "matte", Channel: "sales"}
+		cfg, err := store.Create(ctx, "ana", base)
+		if err != nil {
+			t.Fatalf("%s: create: %v", name, err)
+		}
+		if cfg.Number != 1 || cfg.Selection.ConfigurationID != cfg.ID || cfg.Selection.Channel != "" {
+			t.Fatalf("%s: unexpected first revision %+v", name, cfg)
+		}
+
+		next := base
+		next.Layout = "island"
+		next.Options = []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}}
+		if _, err := store.Save(ctx, cfg.ID, "ben", 1, next); err != nil {
+			t.Fatalf("%s: save: %v", name, err)
+		}
+		if _, err := store.Save(ctx, cfg.ID, "ana", 1, base); !errors.Is(err, ErrConflict) {
+			t.Fatalf("%s: expected a stale save to conflict, got %v", name, err)
+		}
+
+		diff, err := store.Diff(ctx, cfg.ID, 1, 2)
+		if err != nil || len(diff.Fields) != 1 || len(diff.Options) != 1 || diff.Options[0].ID != "island-counter" {
+			t.Fatalf("%s: unexpected diff %+v %v", name, diff, err)
+		}
+
+		restored, err := store.Restore(ctx, cfg.ID, "cy", 1)
+		if err != nil {
+			t.Fatalf("%s: restore: %v", name, err)
+		}
+		if restored.Number != 3 || restored.RestoredFrom != 1 || restored.Author != "cy" || restored.Fingerprint != cfg.Fingerprint {
+			t.Fatalf("%s: unexpected restored revision %+v", name, restored)
+		}
+		if !restored.CreatedAt.Equal(cfg.CreatedAt) || !restored.Revision.CreatedAt.After(cfg.CreatedAt) {
+			t.Fatalf("%s: expected creation and revision times to be kept apart", name)
+		}
+
+		history, err := store.History(ctx, cfg.ID)
+		if err != nil || len(history) != 3 || history[1].Author != "ben" {
+			t.Fatalf("%s: unexpected history %+v %v", name, history, err)
+		}
+	}
+}
+
+func TestStoreRejectsUnknownAndInvalid(t *testing.T) {
+	ctx := context.Background()
+	for name, backend := range backends(t) {
+		store := NewStore(backend)
+		if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("%s: expected not found, got %v", name, err)
+		}
+		if _, err := store.Create(ctx, "", configurator.Selection{Module: "galley"}); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("%s: expected a missing author to be rejected, got %v", name, err)
+		}
+		cfg, err := store.Create(ctx, "ana", configurator.Selection{Module: "galley"})
+		if err != nil {
+			t.Fatalf("%s: create: %v", name, err)
+		}
+		if _, err := store.Revision(ctx, cfg.ID, 2); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("%s: expected an unknown revision, got %v", name, err)
+		}
+	}
+}
+
+func TestFileBackendRejectsPathIDs(t *testing.T) {
+	backend, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("file backend: %v", err)
+	}
+	if err := backend.Append(context.Background(), "../escape", Revision{Number: 1}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected a path id to be rejected, got %v", err)
+	}
+}
+
+func TestFileBackendSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	first, _ := NewFileBackend(dir)
+	cfg, err := NewStore(first).Create(context.Background(), "ana", configurator.Selection{Module: "galley"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	reopened, _ := NewFileBackend(dir)
+	got, err := NewStore(reopened).Get(context.Background(), cfg.ID)
+	if err != nil || got.Fingerprint != cfg.Fingerprint || got.Author != "ana" {
+		t.Fatalf("expected the saved configuration after reopening, got %+v %v", got, err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package evaluate validates and prices a configuration in one call so a
+// blocked configuration is never shown with a price.
+package evaluate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+var tracer = otel.Tracer("github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/evaluate")
+
+// Evaluation statuses.
+const (
+	StatusPriced  = "priced"
+	StatusBlocked = "blocked"
+)
+
+// Validator is the part of the rules service evaluation needs.
+type Validator interface {
+	ValidateWithFixes(ctx context.Context, sel configurator.Selection) (rulesclient.Result, error)
+}
+
+// Evaluation is the combined verdict: violations and fixes from rules, and
+// the estimate and lead time from pricing when the configuration does not
+// block.
+type Evaluation struct {
+	ConfigurationID string                    `json:"configurationId"`
+	Fingerprint     string                    `json:"fingerprint"`
+	Status          string                    `json:"status"`
+	Blocking        bool                      `json:"blocking"`
+	Violations      []rulesclient.Violation   `json:"violations"`
+	Fixes           []rulesclient.Fix         `json:"fixes"`
+	Estimate        *pricing.EstimateResponse `json:"estimate,omitempty"`
+	LeadTimeWeeks   int                       `json:"leadTimeWeeks,omitempty"`
+	Policy          string                    `json:"policy,omitempty"`
+	Locale          string                    `json:"locale,omitempty"`
+	LatencyMicros   int64                     `json:"latencyMicros"`
+}
+
+// Evaluator runs the rules service and the in-process pricing service.
+type Evaluator struct {
+	rules   Validator
+	pricing *pricing.Service
+}
+
+// NewEvaluator wires the rules client and pricing service.
+func NewEvaluator(rules Validator, svc *pricing.Service) *Evaluator {
+	return &Evaluator{rules: rules, pricing: svc}
+}
+
+// Evaluate validates and prices concurrently: estimates are side-effect
+// free, so pricing speculatively costs nothing but CPU, and its result is
+// dropped when rules block. A rules failure fails the evaluation, since an
+// unvalidated price is exactly what this endpoint exists to prevent.
+func (e *Evaluator) Evaluate(ctx context.Context, sel configurator.Selection) (Evaluation, error) {
+	if sel.Currency == "" {
+		sel.Currency = "USD"
+	}
+	if err := sel.Validate(configurator.FieldLayout, configurator.FieldCurrency); err != nil {
+		return Evaluation{}, err
+	}
+
+	ctx, span := tracer.Start(ctx, "configurations.evaluate")
+	defer span.End()
+	start := time.Now()
+
+	var (
+		wg                 sync.WaitGroup
+		verdict            rulesclient.Result
+		estimate           pricing.EstimateResponse
+		rulesErr, priceErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		verdict, rulesErr = e.rules.ValidateWithFixes(ctx, sel)
+	}()
+	go func() {
+		defer wg.Done()
+		pctx, pspan := tracer.Start(ctx, "pricing.estimate")
+		defer pspan.End()
+		estimate, priceErr = e.pricing.Estimate(pctx, sel)
+		if priceErr != nil {
+			pspan.SetStatus(codes.Error, priceErr.Error())
+		}
+	}()
+	wg.Wait()
+
+	if rulesErr != nil {
+		span.SetStatus(codes.Error, rulesErr.Error())
+		return Evaluation{}, rulesErr
+	}
+
+	out := Evaluation{
+		ConfigurationID: sel.ConfigurationID,
+		Fingerprint:     sel.Fingerprint(),
+		Status:          StatusBlocked,
+		Blocking:        verdict.Blocking,
+		Violations:      verdict.Violations,
+		Fixes:           verdict.Fixes,
+		Policy:          verdict.Policy,
+		Locale:          verdict.Locale,
+	}
+	if out.Violations == nil {
+		out.Violations = make([]rulesclient.Violation, 0)
+	}
+	if out.Fixes == nil {
+		out.Fixes = make([]rulesclient.Fix, 0)
+	}
+	if !verdict.Blocking {
+		if priceErr != nil {
+			span.SetStatus(codes.Error, priceErr.Error())
+			return Evaluation{}, priceErr
+		}
+		out.Status = StatusPriced
+		out.Estimate = &estimate
+		out.LeadTimeWeeks = estimate.LeadTimeWeeks
+	}
+	out.LatencyMicros = time.Since(start).Microseconds()
+	span.SetAttributes(
+		attribute.String("evaluate.status", out.Status),
+		attribute.Int("evaluate.violations", len(out.Violations)),
+	)
+	return out, nil
+}
@@ -0,0 +1,70 @@
+package evaluate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+type fakeRules struct {
+	res rulesclient.Result
+	err error
+}
+
+func (f fakeRules) ValidateWithFixes(_ context.Context, sel configurator.Selection) (rulesclient.Result, error) {
+	f.res.ConfigurationID = sel.ConfigurationID
+	return f.res, f.err
+}
+
+func newService() *pricing.Service {
+	return pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{"island-counter": 1200}), nil, 0)
+}
+
+func selection() configurator.Selection {
+	return configurator.Selection{
+		ConfigurationID: "cfg",
+		Module:          "galley",
+		Layout:          "linear",
+		Finish:          "stainless",
+		Options:         []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}},
+	}
+}
+
+func TestEvaluateWithholdsPriceWhenBlocked(t *testing.T) {
+	rules := fakeRules{res: rulesclient.Result{
+		Blocking:   true,
+		Violations: []rulesclient.Violation{{Code: "layout.island-counter", Severity: "error"}},
+		Fixes:      []rulesclient.Fix{{Changes: []rulesclient.Change{{Op: "set-layout", Layout: "island"}}}},
+	}}
+	got, err := NewEvaluator(rules, newService()).Evaluate(context.Background(), selection())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != StatusBlocked || got.Estimate != nil || got.LeadTimeWeeks != 0 || len(got.Fixes) != 1 {
+		t.Fatalf("blocked configuration should carry fixes but no price: %+v", got)
+	}
+}
+
+func TestEvaluatePricesValidConfiguration(t *testing.T) {
+	got, err := NewEvaluator(fakeRules{}, newService()).Evaluate(context.Background(), selection())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != StatusPriced || got.Estimate == nil || got.Estimate.Currency != "USD" || got.ConfigurationID != "cfg" {
+		t.Fatalf("expected a priced evaluation, got %+v", got)
+	}
+	if got.LeadTimeWeeks != 12 || got.Violations == nil || got.Fixes == nil {
+		t.Fatalf("expected stainless lead time and empty lists, got %+v", got)
+	}
+}
+
+func TestEvaluateFailsWithoutRulesVerdict(t *testing.T) {
+	rules := fakeRules{err: rulesclient.ErrUnavailable}
+	if _, err := NewEvaluator(rules, newService()).Evaluate(context.Background(), selection()); !errors.Is(err, rulesclient.ErrUnavailable) {
+		t.Fatalf("expected the rules failure to surface, got %v", err)
+	}
+}
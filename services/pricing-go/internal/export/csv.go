@@ -0,0 +1,47 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) writeRow(cells []cell) error {
+	e.record = e.record[:0]
+	for _, c := range cells {
+		if c.numeric {
+			e.record = append(e.record, strconv.FormatFloat(c.number, 'f', -1, 64))
+			continue
+		}
+		e.record = append(e.record, neutralize(c.text))
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) close() error {
+	return e.flush()
+}
+
+// neutralize stops spreadsheet applications from evaluating text that
+// starts like a formula. Selection fields are caller input.
+func neutralize(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
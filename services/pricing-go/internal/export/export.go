@@ -0,0 +1,265 @@
+// Package export writes estimates and quotes as CSV or XLSX spreadsheets for
+// procurement and dealer systems. Each document is a header block describing
+// the configuration followed by one row per line item, adjustment and tax
+// line. Rows are encoded as they are written, so a batch of many quotes is
+// streamed rather than built in memory.
+package export
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+// Format is a spreadsheet format.
+type Format string
+
+// Supported formats. FormatJSON is what negotiation falls back to; it is not
+// an export format.
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrFormat is returned for formats this package cannot write.
+var ErrFormat = errors.New("unsupported export format")
+
+var contentTypes = map[Format]string{
+	FormatJSON: "application/json",
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ContentType is the media type a format is served as.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return contentTypes[f] + "; charset=utf-8"
+	}
+	return contentTypes[f]
+}
+
+// Negotiate picks the response format. An explicit format parameter wins;
+// otherwise the highest-weighted supported type in the Accept header is
+// used. Anything else, including no preference, is JSON. ok is false for an
+// unknown format parameter.
+func Negotiate(accept, format string) (Format, bool) {
+	if format != "" {
+		f := Format(strings.ToLower(format))
+		_, ok := contentTypes[f]
+		return f, ok
+	}
+	type candidate struct {
+		format Format
+		q      float64
+	}
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		for f, ct := range contentTypes {
+			if ct == mediaType && q > 0 {
+				candidates = append(candidates, candidate{format: f, q: q})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) == 0 {
+		return FormatJSON, true
+	}
+	return candidates[0].format, true
+}
+
+// Document kinds.
+const (
+	KindQuote    = "quote"
+	KindEstimate = "estimate"
+)
+
+// Document is one estimate or quote to export. ValidUntil is left zero for
+// estimates.
+type Document struct {
+	Kind       string
+	ID         string
+	CreatedAt  time.Time
+	ValidUntil time.Time
+	Selection  configurator.Selection
+	Estimate   pricing.EstimateResponse
+}
+
+// Row types in the item table.
+const (
+	RowLine       = "line"
+	RowAdjustment = "adjustment"
+	RowSubtotal   = "subtotal"
+	RowTax        = "tax"
+	RowTotal      = "total"
+	RowMissing    = "missing"
+)
+
+// itemColumns heads the item table of every document.
+var itemColumns = []string{"Reference", "Type", "Category", "Description", "Quantity", "Rate", "Amount", "Currency"}
+
+// cell is one spreadsheet value. Numbers stay numeric in XLSX, and amounts
+// are shown with two decimals there.
+type cell struct {
+	text    string
+	number  float64
+	numeric bool
+	amount  bool
+	bold    bool
+}
+
+func text(s string) cell    { return cell{text: s} }
+func label(s string) cell   { return cell{text: s, bold: true} }
+func number(v float64) cell { return cell{number: v, numeric: true} }
+func amount(v float64) cell { return cell{number: v, numeric: true, amount: true} }
+
+// encoder writes rows in one format.
+type encoder interface {
+	writeRow(cells []cell) error
+	flush() error
+	close() error
+}
+
+// Writer streams documents into one spreadsheet. Close must be called to
+// finish the file.
+type Writer struct {
+	enc   encoder
+	taxes pricing.TaxRates
+	n     int
+}
+
+// NewWriter starts a spreadsheet on w. Taxes are computed per document from
+// the selection's market.
+func NewWriter(w io.Writer, f Format, taxes pricing.TaxRates) (*Writer, error) {
+	var (
+		enc encoder
+		err error
+	)
+	switch f {
+	case FormatCSV:
+		enc = newCSVEncoder(w)
+	case FormatXLSX:
+		enc, err = newXLSXEncoder(w)
+	default:
+		return nil, ErrFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{enc: enc, taxes: taxes}, nil
+}
+
+// Write appends a document: its header block, then its item table.
+// Documents after the first are separated by a blank row.
+func (w *Writer) Write(doc Document) error {
+	return w.write(w.rows(doc))
+}
+
+// WriteMissing records a document that could not be loaded, so a batch
+// keeps one entry per requested ID even after the response has started.
+func (w *Writer) WriteMissing(kind, id, reason string) error {
+	return w.write([][]cell{{text(id), text(RowMissing), text(kind), text(reason)}})
+}
+
+func (w *Writer) write(rows [][]cell) error {
+	if w.n > 0 {
+		rows = append([][]cell{{}}, rows...)
+	}
+	w.n++
+	for _, row := range rows {
+		if err := w.enc.writeRow(row); err != nil {
+			return err
+		}
+	}
+	return w.enc.flush()
+}
+
+// Close finishes the spreadsheet.
+func (w *Writer) Close() error {
+	return w.enc.close()
+}
+
+func (w *Writer) rows(doc Document) [][]cell {
+	est, sel := doc.Estimate, doc.Selection
+	kind := doc.Kind
+	if kind == "" {
+		kind = KindEstimate
+	}
+	ref := doc.ID
+	if ref == "" {
+		ref = est.ConfigurationID
+	}
+
+	header := [][]cell{
+		{label("Kind"), text(kind)},
+		{label("Reference"), text(ref)},
+		{label("Configuration"), text(est.ConfigurationID)},
+		{label("Module"), text(sel.Module)},
+		{label("Layout"), text(sel.Layout)},
+		{label("Finish"), text(sel.Finish)},
+		{label("Market"), text(strings.ToUpper(configurator.NormalizeMarket(sel.Market)))},
+		{label("Currency"), text(est.Currency)},
+		{label("Lead time (weeks)"), number(float64(est.LeadTimeWeeks))},
+		{label("Issued"), text(timestamp(doc.CreatedAt))},
+		{label("Valid until"), text(timestamp(doc.ValidUntil))},
+		{label("Fingerprint"), text(est.Fingerprint)},
+		{},
+	}
+	head := make([]cell, 0, len(itemColumns))
+	for _, c := range itemColumns {
+		head = append(head, label(c))
+	}
+	rows := append(header, head)
+
+	item := func(typ, category, description string, quantity int, rate, value float64) []cell {
+		row := []cell{text(ref), text(typ), text(category), text(description), text(""), text(""), amount(value), text(est.Currency)}
+		if quantity > 0 {
+			row[4] = number(float64(quantity))
+		}
+		if rate > 0 {
+			row[5] = number(rate)
+		}
+		return row
+	}
+	for _, line := range est.Lines {
+		quantity := 0
+		for _, opt := range line.Options {
+			quantity += opt.Quantity
+		}
+		rows = append(rows, item(RowLine, line.Category, line.Name, quantity, 0, line.Amount))
+	}
+	rows = append(rows, item(RowSubtotal, "", "Subtotal", 0, 0, est.Subtotal))
+	for _, adj := range est.Adjustments {
+		rows = append(rows, item(RowAdjustment, "", adj.Reason, 0, 0, adj.Amount))
+	}
+	taxes := w.taxes.Taxes(sel.Market, est.Total)
+	for _, tax := range taxes {
+		rows = append(rows, item(RowTax, "", tax.Label+" "+tax.Market, 0, tax.Rate, tax.Amount))
+	}
+	return append(rows, item(RowTotal, "", "Total", 0, 0, pricing.TaxedTotal(est.Total, taxes)))
+}
+
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+func document(id string) Document {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return Document{
+		Kind:       KindQuote,
+		ID:         id,
+		CreatedAt:  created,
+		ValidUntil: created.Add(30 * 24 * time.Hour),
+		Selection: configurator.Selection{
+			Module: "=galley",
+			Layout: "linear",
+			Finish: "matte",
+			Market: "GB",
+		},
+		Estimate: pricing.EstimateResponse{
+			ConfigurationID: "cfg-1",
+			Fingerprint:     "v1.abc",
+			Currency:        "GBP",
+			Subtotal:        6200,
+			Lines: []pricing.LineItem{{
+				Category: "worktop",
+				Name:     "Worktops",
+				Amount:   1200,
+				Options:  []pricing.LineOption{{ID: "island-counter", Quantity: 1, Amount: 1200}},
+			}},
+			Adjustments:   []pricing.EstimateAdjustment{{Reason: "layout multiplier", Amount: -150.5}},
+			Total:         6049.5,
+			LeadTimeWeeks: 8,
+		},
+	}
+}
+
+var taxes = pricing.TaxRates{"gb": 0.2}
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		accept, format string
+		want           Format
+		ok             bool
+	}{
+		{"", "", FormatJSON, true},
+		{"*/*", "", FormatJSON, true},
+		{"text/csv", "", FormatCSV, true},
+		{"application/json;q=0.5, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "", FormatXLSX, true},
+		{"text/csv;q=0.2, application/json", "", FormatJSON, true},
+		{"text/csv;q=0", "", FormatJSON, true},
+		{"application/json", "CSV", FormatCSV, true},
+		{"", "pdf", Format("pdf"), false},
+	}
+	for _, tc := range cases {
+		got, ok := Negotiate(tc.accept, tc.format)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("Negotiate(%q, %q) = %q, %v; want %q, %v", tc.accept, tc.format, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV, taxes)
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+	if err := w.Write(document("q-1")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// Rows are flushed per document, not held until Close.
+	if !strings.Contains(buf.String(), "q-1,total") {
+		t.Fatalf("expected the first document before Close, got %q", buf.String())
+	}
+	if err := w.WriteMissing(KindQuote, "q-2", "quote not found"); err != nil {
+		t.Fatalf("write missing: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	r := csv.NewReader(&buf)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	rows := make(map[string][]string)
+	for _, rec := range records {
+		if len(rec) > 1 {
+			rows[rec[0]+"/"+rec[1]] = rec
+		}
+	}
+	if got := rows["Module/'=galley"]; got == nil {
+		t.Fatalf("expected a neutralized module in the header block, got %v", records)
+	}
+	if got := rows["q-1/line"]; got[2] != "worktop" || got[4] != "1" || got[6] != "1200" || got[7] != "GBP" {
+		t.Fatalf("unexpected line row %v", got)
+	}
+	if got := rows["q-1/adjustment"]; got[6] != "-150.5" {
+		t.Fatalf("negative amounts must stay numeric, got %v", got)
+	}
+	if got := rows["q-1/tax"]; got[3] != "Tax GB" || got[5] != "0.2" || got[6] != "1209.9" {
+		t.Fatalf("unexpected tax row %v", got)
+	}
+	if got := rows["q-1/total"]; got[6] != "7259.4" {
+		t.Fatalf("unexpected total row %v", got)
+	}
+	if got := rows["q-2/missing"]; got[3] != "quote not found" {
+		t.Fatalf("expected a missing row, got %v", got)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatXLSX, nil)
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+	for _, id := range []string{"q-1", "q-<2>"} {
+		if err := w.Write(document(id)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range z.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		raw, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(raw)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("workbook is missing %s", name)
+		}
+	}
+
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref   string `xml:"r,attr"`
+				Type  string `xml:"t,attr"`
+				Value string `xml:"v"`
+				Text  string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal([]byte(parts["xl/worksheets/sheet1.xml"]), &sheet); err != nil {
+		t.Fatalf("parse sheet: %v", err)
+	}
+	var totals, escaped int
+	for i, row := range sheet.Rows {
+		if row.R != i+1 {
+			t.Fatalf("row %d numbered %d", i+1, row.R)
+		}
+		// Empty cells are omitted, so address cells by reference.
+		cells := make(map[byte]int)
+		for j, c := range row.Cells {
+			cells[c.Ref[0]] = j
+		}
+		if j, ok := cells['B']; ok && row.Cells[j].Text == RowTotal {
+			totals++
+			g := row.Cells[cells['G']]
+			if g.Type != "" || g.Value != "6049.5" {
+				t.Fatalf("untaxed total should be a numeric cell, got %+v", g)
+			}
+		}
+		if len(row.Cells) > 0 && row.Cells[0].Text == "q-<2>" {
+			escaped++
+		}
+	}
+	if totals != 2 || escaped == 0 {
+		t.Fatalf("expected two documents with escaped references, got %d totals", totals)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter(io.Discard, FormatJSON, nil); err != ErrFormat {
+		t.Fatalf("expected ErrFormat, got %v", err)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 7: "H", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := column(i); got != want {
+			t.Fatalf("column(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
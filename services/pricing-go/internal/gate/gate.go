@@ -0,0 +1,118 @@
+// Package gate consults the rules service before pricing hands out numbers.
+// The mode decides what a blocking verdict does; per-operation failure
+// policies decide what happens when rules-go cannot be reached.
+package gate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+)
+
+var (
+	// ErrBlocked is returned in enforce mode when rules block the selection.
+	ErrBlocked = errors.New("configuration is blocked by rules")
+	// ErrUnavailable is returned when rules cannot be reached and the
+	// operation fails closed.
+	ErrUnavailable = errors.New("rules check unavailable")
+)
+
+// Mode controls how a blocking verdict is handled.
+type Mode string
+
+// Modes.
+const (
+	// ModeEnforce refuses blocked selections.
+	ModeEnforce Mode = "enforce"
+	// ModeWarn lets blocked selections through with their violations.
+	ModeWarn Mode = "warn"
+	// ModeOff skips the rules call entirely.
+	ModeOff Mode = "off"
+)
+
+// ParseMode reads a mode name, defaulting to enforce for unknown values.
+func ParseMode(v string) Mode {
+	switch Mode(v) {
+	case ModeWarn, ModeOff:
+		return Mode(v)
+	}
+	return ModeEnforce
+}
+
+// Operation names what is being priced.
+type Operation string
+
+// Operations.
+const (
+	OpEstimate Operation = "estimate"
+	OpQuote    Operation = "quote"
+)
+
+// Policy configures the gate. FailClosed lists the operations refused when
+// rules cannot be reached; every other operation fails open.
+type Policy struct {
+	Mode       Mode
+	FailClosed map[Operation]bool
+}
+
+// Validator is the part of the rules service the gate needs.
+type Validator interface {
+	Validate(ctx context.Context, sel configurator.Selection) (rulesclient.Result, error)
+}
+
+// Check records what the gate saw. Checked is false when the mode is off or
+// the rules service could not be reached and the operation failed open.
+type Check struct {
+	Mode       Mode                    `json:"mode"`
+	Checked    bool                    `json:"checked"`
+	Blocking   bool                    `json:"blocking"`
+	Violations []rulesclient.Violation `json:"violations"`
+	Policy     string                  `json:"policy,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// Gate asks rules-go for a verdict before pricing.
+type Gate struct {
+	rules  Validator
+	policy Policy
+}
+
+// New returns a gate using the given rules client and policy.
+func New(rules Validator, policy Policy) *Gate {
+	return &Gate{rules: rules, policy: policy}
+}
+
+// Check validates the selection for an operation. The returned Check is
+// always filled in so callers can show violations alongside ErrBlocked.
+func (g *Gate) Check(ctx context.Context, sel configurator.Selection, op Operation) (Check, error) {
+	check := Check{Mode: g.policy.Mode, Violations: make([]rulesclient.Violation, 0)}
+	if g.policy.Mode == ModeOff {
+		return check, nil
+	}
+
+	res, err := g.rules.Validate(ctx, sel)
+	if errors.Is(err, rulesclient.ErrUnavailable) {
+		check.Error = err.Error()
+		if g.policy.FailClosed[op] {
+			return check, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+		return check, nil
+	}
+	if err != nil {
+		return check, err
+	}
+
+	check.Checked = true
+	check.Blocking = res.Blocking
+	check.Policy = res.Policy
+	if res.Violations != nil {
+		check.Violations = res.Violations
+	}
+	if res.Blocking && g.policy.Mode == ModeEnforce {
+		return check, ErrBlocked
+	}
+	return check, nil
+}
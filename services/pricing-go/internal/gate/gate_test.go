@@ -0,0 +1,69 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+)
+
+type fakeRules struct {
+	res   rulesclient.Result
+	err   error
+	calls int
+}
+
+func (f *fakeRules) Validate(context.Context, configurator.Selection) (rulesclient.Result, error) {
+	f.calls++
+	return f.res, f.err
+}
+
+var blocked = rulesclient.Result{
+	Blocking:   true,
+	Violations: []rulesclient.Violation{{Code: "layout.island-counter", Severity: "error"}},
+}
+
+func policy(mode Mode) Policy {
+	return Policy{Mode: mode, FailClosed: map[Operation]bool{OpQuote: true}}
+}
+
+func TestModesHandleBlockingVerdicts(t *testing.T) {
+	sel := configurator.Selection{Module: "galley", Layout: "linear"}
+
+	check, err := New(&fakeRules{res: blocked}, policy(ModeEnforce)).Check(context.Background(), sel, OpQuote)
+	if !errors.Is(err, ErrBlocked) || !check.Blocking || len(check.Violations) != 1 {
+		t.Fatalf("enforce should refuse with violations, got %+v %v", check, err)
+	}
+
+	check, err = New(&fakeRules{res: blocked}, policy(ModeWarn)).Check(context.Background(), sel, OpQuote)
+	if err != nil || !check.Checked || !check.Blocking {
+		t.Fatalf("warn should pass with violations, got %+v %v", check, err)
+	}
+
+	rules := &fakeRules{res: blocked}
+	check, err = New(rules, policy(ModeOff)).Check(context.Background(), sel, OpQuote)
+	if err != nil || check.Checked || rules.calls != 0 {
+		t.Fatalf("off should not call rules, got %+v %v", check, err)
+	}
+}
+
+func TestUnreachableRulesFollowFailurePolicy(t *testing.T) {
+	sel := configurator.Selection{Module: "galley", Layout: "linear"}
+	g := New(&fakeRules{err: rulesclient.ErrUnavailable}, policy(ModeEnforce))
+
+	if _, err := g.Check(context.Background(), sel, OpQuote); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("quotes should fail closed, got %v", err)
+	}
+	check, err := g.Check(context.Background(), sel, OpEstimate)
+	if err != nil || check.Checked || check.Error == "" {
+		t.Fatalf("estimates should fail open and record why, got %+v %v", check, err)
+	}
+}
+
+func TestParseModeDefaultsToEnforce(t *testing.T) {
+	if ParseMode("warn") != ModeWarn || ParseMode("off") != ModeOff || ParseMode("strict") != ModeEnforce {
+		t.Fatalf("unexpected mode parsing")
+	}
+}
@@ -0,0 +1,188 @@
+// Package grpcapi serves the pricing API over gRPC. It mirrors the chi
+// estimate endpoint, rules gate included, so both transports price a
+// selection identically.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	configuratorv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/configurator/v1"
+	pricingv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/pricing/v1"
+	rulesv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/rules/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+const (
+	// maxBatch bounds one BatchEstimate call.
+	maxBatch = 100
+	// batchWorkers bounds how many selections of a batch are priced at once.
+	batchWorkers = 8
+	// estimateTimeout matches the HTTP handler's per-estimate deadline.
+	estimateTimeout = 2 * time.Second
+)
+
+// Server implements pricingv1.PricingServiceServer.
+type Server struct {
+	pricingv1.UnimplementedPricingServiceServer
+
+	log  zerolog.Logger
+	svc  *pricing.Service
+	gate *gate.Gate
+}
+
+// NewServer prices with svc. A nil gate prices without a rules check, like
+// the HTTP handler.
+func NewServer(log zerolog.Logger, svc *pricing.Service, g *gate.Gate) *Server {
+	return &Server{log: log, svc: svc, gate: g}
+}
+
+// Estimate prices one selection.
+func (s *Server) Estimate(ctx context.Context, req *pricingv1.EstimateRequest) (*pricingv1.EstimateResponse, error) {
+	resp, st := s.estimate(ctx, req)
+	if st != nil {
+		return nil, st.Err()
+	}
+	return resp, nil
+}
+
+// BatchEstimate prices each selection independently; one failure does not
+// fail the batch. Once the call is cancelled no further selections are
+// started and the call fails with the context's status.
+func (s *Server) BatchEstimate(ctx context.Context, req *pricingv1.BatchEstimateRequest) (*pricingv1.BatchEstimateResponse, error) {
+	if n := len(req.GetRequests()); n == 0 || n > maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "requests must list between 1 and %d selections", maxBatch)
+	}
+	results := make([]*pricingv1.BatchEstimateResult, len(req.GetRequests()))
+	sem := make(chan struct{}, batchWorkers)
+	var wg sync.WaitGroup
+	for i, item := range req.GetRequests() {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return nil, status.FromContextError(err).Err()
+		}
+		wg.Add(1)
+		go func(i int, item *pricingv1.EstimateRequest) {
+			defer func() { <-sem; wg.Done() }()
+			resp, st := s.estimate(ctx, item)
+			if st != nil {
+				results[i] = &pricingv1.BatchEstimateResult{Outcome: &pricingv1.BatchEstimateResult_Error{Error: st.Proto()}}
+				return
+			}
+			results[i] = &pricingv1.BatchEstimateResult{Outcome: &pricingv1.BatchEstimateResult_Response{Response: resp}}
+		}(i, item)
+	}
+	wg.Wait()
+	return &pricingv1.BatchEstimateResponse{Results: results}, nil
+}
+
+func (s *Server) estimate(ctx context.Context, req *pricingv1.EstimateRequest) (*pricingv1.EstimateResponse, *status.Status) {
+	sel := configuratorv1.ToSelection(req.GetSelection())
+	if sel.ConfigurationID == "" {
+		sel.ConfigurationID = uuid.NewString()
+	}
+	// The rules service derives the channel from pricing's API key; neither
+	// the selection nor x-channel metadata may pick a laxer policy.
+	sel.Channel = ""
+
+	ctx, cancel := context.WithTimeout(ctx, estimateTimeout)
+	defer cancel()
+
+	var check *gate.Check
+	if s.gate != nil {
+		c, err := s.gate.Check(ctx, sel, gate.OpEstimate)
+		if err != nil {
+			return nil, s.gateStatus(ctx, c, err)
+		}
+		check = &c
+	}
+
+	resp, err := s.svc.Estimate(ctx, sel)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("estimate failed")
+		// A timed out or cancelled item is not the caller's mistake; its
+		// context status lets the caller retry.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr)
+		}
+		return nil, status.New(codes.InvalidArgument, err.Error())
+	}
+	return estimateResponse(resp, check), nil
+}
+
+// gateStatus maps gate failures onto the codes the HTTP handler's statuses
+// correspond to. Unclassified failures after the deadline get the context's
+// status.
+func (s *Server) gateStatus(ctx context.Context, check gate.Check, err error) *status.Status {
+	var rejected *rulesclient.Error
+	switch {
+	case errors.Is(err, gate.ErrBlocked):
+		st := status.New(codes.FailedPrecondition, err.Error())
+		if detailed, derr := st.WithDetails(rulesCheck(check)); derr == nil {
+			return detailed
+		}
+		return st
+	case errors.Is(err, gate.ErrUnavailable):
+		s.log.Error().Err(err).Msg("rules check unavailable")
+		return status.New(codes.Unavailable, "rules check unavailable")
+	case errors.As(err, &rejected):
+		return status.New(codes.InvalidArgument, rejected.Message)
+	case ctx.Err() != nil:
+		s.log.Warn().Err(err).Msg("pricing request timed out")
+		return status.FromContextError(ctx.Err())
+	default:
+		s.log.Warn().Err(err).Msg("pricing request failed")
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+}
+
+func estimateResponse(resp pricing.EstimateResponse, check *gate.Check) *pricingv1.EstimateResponse {
+	out := &pricingv1.EstimateResponse{
+		ConfigurationId: resp.ConfigurationID,
+		Fingerprint:     resp.Fingerprint,
+		Currency:        resp.Currency,
+		Subtotal:        resp.Subtotal,
+		Total:           resp.Total,
+		LeadTimeWeeks:   int32(resp.LeadTimeWeeks),
+		LatencyMicros:   resp.LatencyMicros,
+		Cached:          resp.Cached,
+	}
+	for _, line := range resp.Lines {
+		item := &pricingv1.LineItem{Category: line.Category, Name: line.Name, Amount: line.Amount}
+		for _, opt := range line.Options {
+			item.Options = append(item.Options, &pricingv1.LineOption{Id: opt.ID, Quantity: int32(opt.Quantity), Amount: opt.Amount})
+		}
+		out.Lines = append(out.Lines, item)
+	}
+	for _, adj := range resp.Adjustments {
+		out.Adjustments = append(out.Adjustments, &pricingv1.Adjustment{Reason: adj.Reason, Amount: adj.Amount})
+	}
+	if check != nil {
+		out.Rules = rulesCheck(*check)
+	}
+	return out
+}
+
+func rulesCheck(check gate.Check) *pricingv1.RulesCheck {
+	return &pricingv1.RulesCheck{
+		Mode:       string(check.Mode),
+		Checked:    check.Checked,
+		Blocking:   check.Blocking,
+		Violations: rulesv1.FromViolations(check.Violations),
+		Policy:     check.Policy,
+		Error:      check.Error,
+	}
+}
@@ -0,0 +1,199 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	configuratorv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/configurator/v1"
+	pricingv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/pricing/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rpc"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+type fakeRules struct {
+	blocked map[string]bool
+	err     error
+}
+
+func (f fakeRules) Validate(_ context.Context, sel configurator.Selection) (rulesclient.Result, error) {
+	if f.err != nil {
+		return rulesclient.Result{}, f.err
+	}
+	if f.blocked[sel.Layout] {
+		return rulesclient.Result{Blocking: true, Violations: []rulesclient.Violation{{
+			Code:     "layout.island-counter",
+			Severity: "error",
+			Params:   map[string]any{"layout": sel.Layout},
+		}}}, nil
+	}
+	return rulesclient.Result{Violations: []rulesclient.Violation{}}, nil
+}
+
+// channelRules records the channel each selection reached the rules
+// service with.
+type channelRules struct {
+	mu       sync.Mutex
+	channels []string
+}
+
+func (c *channelRules) Validate(_ context.Context, sel configurator.Selection) (rulesclient.Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.channels = append(c.channels, sel.Channel)
+	return rulesclient.Result{Violations: []rulesclient.Violation{}}, nil
+}
+
+func newServer(rules gate.Validator, policy gate.Policy) *Server {
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{"island-counter": 1200}), nil, 0)
+	return NewServer(zerolog.Nop(), svc, gate.New(rules, policy))
+}
+
+func newClient(t *testing.T, rules gate.Validator, policy gate.Policy) pricingv1.PricingServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := rpc.NewServer()
+	pricingv1.RegisterPricingServiceServer(srv, newServer(rules, policy))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := rpc.Dial("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pricingv1.NewPricingServiceClient(conn)
+}
+
+func request(layout string) *pricingv1.EstimateRequest {
+	return &pricingv1.EstimateRequest{Selection: configuratorv1.FromSelection(configurator.Selection{
+		ConfigurationID: "cfg-" + layout,
+		Module:          "galley",
+		Layout:          layout,
+		Finish:          "stainless",
+		Options:         []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}},
+	})}
+}
+
+func TestEstimate(t *testing.T) {
+	client := newClient(t, fakeRules{}, gate.Policy{Mode: gate.ModeEnforce})
+	resp, err := client.Estimate(context.Background(), request("linear"))
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if resp.ConfigurationId != "cfg-linear" || resp.Currency != "USD" || resp.Total <= 0 || resp.Fingerprint == "" {
+		t.Fatalf("unexpected estimate: %v", resp)
+	}
+	if resp.Rules == nil || !resp.Rules.Checked || resp.Rules.Mode != "enforce" {
+		t.Fatalf("expected a rules check, got %v", resp.Rules)
+	}
+}
+
+func TestEstimateErrors(t *testing.T) {
+	client := newClient(t, fakeRules{blocked: map[string]bool{"linear": true}}, gate.Policy{Mode: gate.ModeEnforce})
+	_, err := client.Estimate(context.Background(), request("linear"))
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FAILED_PRECONDITION, got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected the rules check in the details, got %v", st.Details())
+	}
+	check, ok := st.Details()[0].(*pricingv1.RulesCheck)
+	if !ok || !check.Blocking || len(check.Violations) != 1 || check.Violations[0].Params.AsMap()["layout"] != "linear" {
+		t.Fatalf("unexpected rules check detail: %v", st.Details()[0])
+	}
+
+	_, err = client.Estimate(context.Background(), &pricingv1.EstimateRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for an empty selection, got %v", err)
+	}
+
+	closed := newClient(t, fakeRules{err: rulesclient.ErrUnavailable}, gate.Policy{Mode: gate.ModeEnforce, FailClosed: map[gate.Operation]bool{gate.OpEstimate: true}})
+	_, err = closed.Estimate(context.Background(), request("linear"))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected UNAVAILABLE, got %v", err)
+	}
+}
+
+func TestBatchEstimate(t *testing.T) {
+	client := newClient(t, fakeRules{blocked: map[string]bool{"island": true}}, gate.Policy{Mode: gate.ModeEnforce})
+	resp, err := client.BatchEstimate(context.Background(), &pricingv1.BatchEstimateRequest{
+		Requests: []*pricingv1.EstimateRequest{request("linear"), request("island"), request("l-shape")},
+	})
+	if err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	if got := resp.Results[0].GetResponse().GetConfigurationId(); got != "cfg-linear" {
+		t.Fatalf("results out of order: %q", got)
+	}
+	if got := resp.Results[1].GetError(); got == nil || codes.Code(got.Code) != codes.FailedPrecondition {
+		t.Fatalf("expected the blocked selection to fail alone, got %v", resp.Results[1])
+	}
+	if resp.Results[2].GetResponse() == nil {
+		t.Fatalf("expected the third selection to be priced, got %v", resp.Results[2])
+	}
+
+	if _, err := client.BatchEstimate(context.Background(), &pricingv1.BatchEstimateRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for an empty batch, got %v", err)
+	}
+}
+
+func TestEstimateIgnoresTheClientsChannel(t *testing.T) {
+	rules := &channelRules{}
+	client := newClient(t, rules, gate.Policy{Mode: gate.ModeEnforce})
+	req := request("linear")
+	req.Selection.Channel = "admin"
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-channel", "admin"))
+	if _, err := client.Estimate(ctx, req); err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if len(rules.channels) != 1 || rules.channels[0] != "" {
+		t.Fatalf("expected the rules check without a client channel, got %q", rules.channels)
+	}
+}
+
+func TestBatchEstimateStopsWhenCancelled(t *testing.T) {
+	rules := &channelRules{}
+	srv := newServer(rules, gate.Policy{Mode: gate.ModeEnforce})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := srv.BatchEstimate(ctx, &pricingv1.BatchEstimateRequest{Requests: []*pricingv1.EstimateRequest{request("linear"), request("island")}})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("expected CANCELED for a cancelled batch, got %v", err)
+	}
+	if len(rules.channels) != 0 {
+		t.Fatalf("expected no selections started, got %d", len(rules.channels))
+	}
+}
+
+func TestEstimateReportsTimeoutsAsContextErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	srv := newServer(fakeRules{err: context.Canceled}, gate.Policy{Mode: gate.ModeEnforce})
+	if _, err := srv.Estimate(ctx, request("linear")); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected CANCELED when the rules check is cut off, got %v", err)
+	}
+
+	unchecked := newServer(fakeRules{}, gate.Policy{Mode: gate.ModeOff})
+	if _, err := unchecked.Estimate(ctx, &pricingv1.EstimateRequest{}); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected CANCELED for an estimate cut off by its deadline, got %v", err)
+	}
+}
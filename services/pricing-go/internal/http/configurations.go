@@ -0,0 +1,166 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/configstore"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+// saveRequest is the body of create and save calls. BaseRevision guards
+// saves against concurrent edits; zero skips the check.
+type saveRequest struct {
+	BaseRevision int               `json:"baseRevision"`
+	Selection    pricing.Selection `json:"selection"`
+}
+
+// requireAuthor authenticates the bearer key; its principal is the author
+// recorded on every revision the request writes.
+func (h *handler) requireAuthor(keys auth.Keys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			author, ok := keys.Lookup(auth.Bearer(r.Header.Get("Authorization")))
+			if !ok {
+				h.respondError(w, http.StatusUnauthorized, auth.ErrUnauthorized.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), author)))
+		})
+	}
+}
+
+// author is the authenticated caller, never a name taken from the body.
+func author(r *http.Request) string {
+	principal, _ := auth.FromContext(r.Context())
+	return principal
+}
+
+func (h *handler) createConfiguration(w http.ResponseWriter, r *http.Request) {
+	var req saveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	cfg, err := h.configs.Create(r.Context(), author(r), req.Selection)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, cfg)
+}
+
+func (h *handler) getConfiguration(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.configs.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, cfg)
+}
+
+func (h *handler) saveConfiguration(w http.ResponseWriter, r *http.Request) {
+	var req saveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	cfg, err := h.configs.Save(r.Context(), chi.URLParam(r, "id"), author(r), req.BaseRevision, req.Selection)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, cfg)
+}
+
+func (h *handler) listRevisions(w http.ResponseWriter, r *http.Request) {
+	history, err := h.configs.History(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]any{"revisions": history})
+}
+
+func (h *handler) getRevision(w http.ResponseWriter, r *http.Request) {
+	n, ok := h.revisionParam(w, chi.URLParam(r, "rev"))
+	if !ok {
+		return
+	}
+	rev, err := h.configs.Revision(r.Context(), chi.URLParam(r, "id"), n)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, rev)
+}
+
+func (h *handler) restoreRevision(w http.ResponseWriter, r *http.Request) {
+	n, ok := h.revisionParam(w, chi.URLParam(r, "rev"))
+	if !ok {
+		return
+	}
+	cfg, err := h.configs.Restore(r.Context(), chi.URLParam(r, "id"), author(r), n)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, cfg)
+}
+
+// diffRevisions compares ?from and ?to, defaulting to the previous and the
+// latest revision.
+func (h *handler) diffRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	cfg, err := h.configs.Get(r.Context(), id)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	from, to, ok := max(cfg.Number-1, 1), cfg.Number, true
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, ok = h.revisionParam(w, v); !ok {
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, ok = h.revisionParam(w, v); !ok {
+			return
+		}
+	}
+	diff, err := h.configs.Diff(r.Context(), id, from, to)
+	if err != nil {
+		h.respondStoreError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]any{"from": from, "to": to, "diff": diff})
+}
+
+func (h *handler) revisionParam(w http.ResponseWriter, v string) (int, bool) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		h.respondError(w, http.StatusBadRequest, "revision must be a positive integer")
+		return 0, false
+	}
+	return n, true
+}
+
+func (h *handler) respondStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, configstore.ErrNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, configstore.ErrConflict):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, configstore.ErrInvalid):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error().Err(err).Msg("configuration store failed")
+		h.respondError(w, http.StatusInternalServerError, "configuration store failed")
+	}
+}
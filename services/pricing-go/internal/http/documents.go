@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quote"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quotepdf"
+)
+
+// quotePDF renders a persisted quote.
+func (h *handler) quotePDF(w http.ResponseWriter, r *http.Request) {
+	q, err := h.quotes.Get(r.Context(), chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, quote.ErrNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		h.log.Error().Err(err).Msg("load quote failed")
+		h.respondError(w, http.StatusInternalServerError, "load quote failed")
+		return
+	}
+	h.respondPDF(w, "quote-"+q.ID+".pdf", quotepdf.Document{
+		Kind:       quotepdf.KindQuote,
+		ID:         q.ID,
+		CreatedAt:  q.CreatedAt,
+		ValidUntil: q.ValidUntil,
+		Selection:  q.Selection,
+		Estimate:   q.Estimate,
+		Warnings:   q.Rules.Violations,
+		ShareCode:  q.ShareCode,
+		ShareURL:   q.ShareURL,
+	})
+}
+
+// estimatePDF prices a selection like /v1/pricing/estimate and renders the
+// result with a share code when sharing is enabled. Estimates are not stored
+// and carry no validity date. A failed share is logged and left off the PDF
+// rather than failing the download.
+func (h *handler) estimatePDF(w http.ResponseWriter, r *http.Request) {
+	var payload pricing.Selection
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	payload = h.canonical(payload)
+	if payload.ConfigurationID == "" {
+		payload.ConfigurationID = uuid.NewString()
+	}
+	payload.Channel = ""
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	out, ok := h.checkedEstimate(ctx, w, payload)
+	if !ok {
+		return
+	}
+	doc := quotepdf.Document{
+		Kind:      quotepdf.KindEstimate,
+		CreatedAt: time.Now().UTC(),
+		Selection: payload,
+		Estimate:  out.EstimateResponse,
+	}
+	if out.Rules != nil {
+		doc.Warnings = out.Rules.Violations
+	}
+	if h.shares != nil {
+		if s, err := h.shares.Create(ctx, payload); err == nil {
+			doc.ShareCode, doc.ShareURL = s.Code, s.URL
+		} else {
+			h.log.Warn().Err(err).Msg("share code for PDF failed")
+		}
+	}
+	h.respondPDF(w, "estimate-"+payload.ConfigurationID+".pdf", doc)
+}
+
+// respondPDF renders the document and writes it.
+func (h *handler) respondPDF(w http.ResponseWriter, filename string, doc quotepdf.Document) {
+	var buf bytes.Buffer
+	if err := h.documents.Render(&buf, doc); err != nil {
+		h.log.Error().Err(err).Msg("render PDF failed")
+		h.respondError(w, http.StatusInternalServerError, "render PDF failed")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": filename}))
+	w.Header().Set("X-Configuration-Fingerprint", doc.Estimate.Fingerprint)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		h.log.Error().Err(err).Msg("failed to write PDF")
+	}
+}
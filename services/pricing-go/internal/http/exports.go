@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/export"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quote"
+)
+
+// maxExportQuotes bounds one batch export request.
+const maxExportQuotes = 500
+
+// exportTimeout is the batch export deadline. It stays below the server's
+// 20s write timeout so an export that runs out of time is aborted by the
+// handler rather than cut by the server.
+const exportTimeout = 15 * time.Second
+
+// exportFormat negotiates the response format from ?format= and Accept. An
+// unknown format is answered with 400 and reported as not ok.
+func (h *handler) exportFormat(w http.ResponseWriter, r *http.Request) (export.Format, bool) {
+	f, ok := export.Negotiate(r.Header.Get("Accept"), r.URL.Query().Get("format"))
+	if !ok {
+		h.respondError(w, http.StatusBadRequest, "unsupported format "+string(f))
+	}
+	return f, ok
+}
+
+// exportQuotes streams many stored quotes into one spreadsheet, CSV unless
+// XLSX is asked for. IDs that are unknown or expired get a "missing" row,
+// since the status line has already been sent by the time they are reached.
+// Running past exportTimeout aborts the response.
+func (h *handler) exportQuotes(w http.ResponseWriter, r *http.Request) {
+	f, ok := h.exportFormat(w, r)
+	if !ok {
+		return
+	}
+	if f == export.FormatJSON {
+		f = export.FormatCSV
+	}
+	var payload struct {
+		IDs []string `json:"ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	if len(payload.IDs) == 0 || len(payload.IDs) > maxExportQuotes {
+		h.respondError(w, http.StatusBadRequest, fmt.Sprintf("ids must list between 1 and %d quotes", maxExportQuotes))
+		return
+	}
+
+	h.respondExport(w, f, "quotes", func(out *export.Writer) error {
+		for _, id := range payload.IDs {
+			if err := r.Context().Err(); err != nil {
+				return err
+			}
+			q, err := h.quotes.Get(r.Context(), id)
+			switch {
+			case errors.Is(err, quote.ErrNotFound):
+				err = out.WriteMissing(export.KindQuote, id, err.Error())
+			case err != nil:
+				return err
+			default:
+				err = out.Write(quoteDocument(q))
+			}
+			if err != nil {
+				return err
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+		}
+		return nil
+	})
+}
+
+// respondExport writes a spreadsheet attachment. The writer is created before
+// the status is sent, so a failure to start the file is still a 500. Errors
+// after that are logged and abort the response, so the client sees a broken
+// transfer instead of a file that merely ends early.
+func (h *handler) respondExport(w http.ResponseWriter, f export.Format, name string, write func(*export.Writer) error) {
+	start := &startWriter{}
+	out, err := export.NewWriter(start, f, h.taxes)
+	if err != nil {
+		h.log.Error().Err(err).Str("format", string(f)).Msg("export failed")
+		h.respondError(w, http.StatusInternalServerError, "export failed")
+		return
+	}
+	w.Header().Set("Content-Type", f.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + string(f)}))
+	w.WriteHeader(http.StatusOK)
+
+	err = start.commit(w)
+	if err == nil {
+		err = write(out)
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		h.log.Error().Err(err).Str("format", string(f)).Msg("export failed")
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// startWriter holds what a writer emits while it is created, before the
+// status is sent, then passes writes straight through once committed.
+type startWriter struct {
+	buf bytes.Buffer
+	dst io.Writer
+}
+
+func (s *startWriter) Write(p []byte) (int, error) {
+	if s.dst != nil {
+		return s.dst.Write(p)
+	}
+	return s.buf.Write(p)
+}
+
+func (s *startWriter) commit(dst io.Writer) error {
+	s.dst = dst
+	_, err := s.buf.WriteTo(dst)
+	return err
+}
+
+func quoteDocument(q quote.Quote) export.Document {
+	return export.Document{
+		Kind:       export.KindQuote,
+		ID:         q.ID,
+		CreatedAt:  q.CreatedAt,
+		ValidUntil: q.ValidUntil,
+		Selection:  q.Selection,
+		Estimate:   q.Estimate,
+	}
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -13,36 +15,135 @@ import (
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/bom"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/catalogimport"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/configstore"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/evaluate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/export"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
 	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quote"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quotepdf"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/share"
 )
 
+// maxCatalogBytes bounds a gateway catalog export upload.
+const maxCatalogBytes = 8 << 20
+
+// Options carries the optional parts of the HTTP surface.
+type Options struct {
+	// Evaluator mounts the combined validate-and-price endpoint when set.
+	Evaluator *evaluate.Evaluator
+	// Gate consults rules before estimates; nil prices without a check.
+	Gate *gate.Gate
+	// Quotes mounts the persistent quote endpoints when set.
+	Quotes *quote.Service
+	// Catalog mounts the gateway catalog import and resolve endpoints when set.
+	Catalog *catalogimport.Resolver
+	// Shares mounts the share code endpoints when set.
+	Shares *share.Service
+	// Configurations mounts the saved configuration endpoints when set and
+	// AuthorKeys holds at least one key.
+	Configurations *configstore.Store
+	// AuthorKeys maps bearer keys to the author recorded on saved revisions.
+	AuthorKeys auth.Keys
+	// BOM mounts the bill of materials endpoint when set.
+	BOM *bom.Rules
+	// Documents mounts the PDF endpoints for estimates and, with Quotes,
+	// for stored quotes when set.
+	Documents *quotepdf.Renderer
+	// Taxes adds tax lines to CSV and XLSX exports.
+	Taxes pricing.TaxRates
+}
+
 // NewHTTPHandler wires chi, middleware, and our pricing endpoints.
-func NewHTTPHandler(log zerolog.Logger, svc *pricing.Service) http.Handler {
+func NewHTTPHandler(log zerolog.Logger, svc *pricing.Service, opts Options) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(2 * time.Second))
 	r.Use(otelhttp.NewMiddleware("pricing-go-http"))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
 		AllowCredentials: true,
 	}))
 
-	h := &handler{log: log, svc: svc}
+	h := &handler{log: log, svc: svc, evaluator: opts.Evaluator, gate: opts.Gate, quotes: opts.Quotes, catalog: opts.Catalog, shares: opts.Shares, configs: opts.Configurations, bom: opts.BOM, documents: opts.Documents, taxes: opts.Taxes}
 
-	r.Get("/healthz", h.health)
-	r.Post("/v1/pricing/estimate", h.estimate)
+	// Batch exports stream up to maxExportQuotes quotes and get their own,
+	// longer deadline; every other route answers within two seconds.
+	if opts.Quotes != nil {
+		r.With(middleware.Timeout(exportTimeout)).Post("/v1/pricing/quotes/export", h.exportQuotes)
+	}
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(2 * time.Second))
+		r.Get("/healthz", h.health)
+		r.Post("/v1/pricing/estimate", h.estimate)
+		if opts.Quotes != nil {
+			r.Post("/v1/pricing/quotes", h.createQuote)
+			r.Get("/v1/pricing/quotes/{id}", h.getQuote)
+			if opts.Documents != nil {
+				r.Get("/v1/pricing/quotes/{id}.pdf", h.quotePDF)
+			}
+		}
+		if opts.Documents != nil {
+			r.Post("/v1/pricing/estimate.pdf", h.estimatePDF)
+		}
+		if opts.Catalog != nil {
+			r.Post("/v1/pricing/catalog/import", h.importCatalog)
+			r.Get("/v1/pricing/catalog/resolve/{id}", h.resolveCatalog)
+		}
+		if opts.Evaluator != nil {
+			r.Post("/v1/configurations/evaluate", h.evaluate)
+		}
+		if opts.Shares != nil {
+			r.Post("/v1/configurations/shares", h.createShare)
+			r.Get("/v1/configurations/shares/{code}", h.resolveShare)
+		}
+		if opts.BOM != nil {
+			r.Post("/v1/bom", h.expandBOM)
+		}
+		if opts.Configurations != nil && opts.AuthorKeys.Len() > 0 {
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireAuthor(opts.AuthorKeys))
+				r.Post("/v1/configurations", h.createConfiguration)
+				r.Get("/v1/configurations/{id}", h.getConfiguration)
+				r.Put("/v1/configurations/{id}", h.saveConfiguration)
+				r.Get("/v1/configurations/{id}/revisions", h.listRevisions)
+				r.Get("/v1/configurations/{id}/revisions/{rev}", h.getRevision)
+				r.Post("/v1/configurations/{id}/revisions/{rev}/restore", h.restoreRevision)
+				r.Get("/v1/configurations/{id}/diff", h.diffRevisions)
+			})
+		}
+	})
 
 	return r
 }
 
 type handler struct {
-	log zerolog.Logger
-	svc *pricing.Service
+	log       zerolog.Logger
+	svc       *pricing.Service
+	evaluator *evaluate.Evaluator
+	gate      *gate.Gate
+	quotes    *quote.Service
+	catalog   *catalogimport.Resolver
+	shares    *share.Service
+	configs   *configstore.Store
+	bom       *bom.Rules
+	documents *quotepdf.Renderer
+	taxes     pricing.TaxRates
+}
+
+// estimateResponse adds the rules check to an estimate when a gate is set.
+type estimateResponse struct {
+	pricing.EstimateResponse
+	Rules *gate.Check `json:"rules,omitempty"`
 }
 
 func (h *handler) health(w http.ResponseWriter, _ *http.Request) {
@@ -53,29 +154,279 @@ func (h *handler) health(w http.ResponseWriter, _ *http.Request) {
 
 func (h *handler) estimate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	format, ok := h.exportFormat(w, r)
+	if !ok {
+		return
+	}
 	var payload pricing.Selection
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		h.respondError(w, http.StatusBadRequest, "invalid payload")
 		return
 	}
+	payload = h.canonical(payload)
 	if payload.ConfigurationID == "" {
 		payload.ConfigurationID = uuid.NewString()
 	}
+	// The rules service derives the channel from our API key; the client's
+	// choice is dropped so it is neither honoured nor recorded.
+	payload.Channel = ""
 
 	// Ensure we don't hold the request open forever even if upstream callers
 	// remove the middleware timeout.
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
+	out, ok := h.checkedEstimate(ctx, w, payload)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("X-Configuration-Fingerprint", out.Fingerprint)
+	if format != export.FormatJSON {
+		h.respondExport(w, format, "estimate-"+out.ConfigurationID, func(ew *export.Writer) error {
+			return ew.Write(export.Document{Kind: export.KindEstimate, CreatedAt: time.Now().UTC(), Selection: payload, Estimate: out.EstimateResponse})
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.log.Error().Err(err).Msg("failed to encode response")
+	}
+}
+
+// checkedEstimate consults the rules gate, when set, and prices the
+// selection. On failure it writes the error response and reports false.
+func (h *handler) checkedEstimate(ctx context.Context, w http.ResponseWriter, payload pricing.Selection) (estimateResponse, bool) {
+	out := estimateResponse{}
+	if h.gate != nil {
+		check, err := h.gate.Check(ctx, payload, gate.OpEstimate)
+		if err != nil {
+			h.respondGateError(w, check, err)
+			return out, false
+		}
+		out.Rules = &check
+	}
+
 	resp, err := h.svc.Estimate(ctx, payload)
 	if err != nil {
 		h.log.Warn().Err(err).Msg("estimate failed")
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return out, false
+	}
+	out.EstimateResponse = resp
+	return out, true
+}
+
+func (h *handler) createQuote(w http.ResponseWriter, r *http.Request) {
+	var payload pricing.Selection
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	payload = h.canonical(payload)
+	payload.Channel = ""
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	q, err := h.quotes.Create(ctx, payload)
+	if errors.Is(err, quote.ErrInvalid) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.respondGateError(w, q.Rules, err)
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, q)
+}
+
+func (h *handler) getQuote(w http.ResponseWriter, r *http.Request) {
+	format, ok := h.exportFormat(w, r)
+	if !ok {
+		return
+	}
+	q, err := h.quotes.Get(r.Context(), chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, quote.ErrNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		h.log.Error().Err(err).Msg("load quote failed")
+		h.respondError(w, http.StatusInternalServerError, "load quote failed")
+		return
+	}
+	if format != export.FormatJSON {
+		h.respondExport(w, format, "quote-"+q.ID, func(ew *export.Writer) error {
+			return ew.Write(quoteDocument(q))
+		})
+		return
+	}
+	h.respondJSON(w, http.StatusOK, q)
+}
+
+// importCatalog joins a gateway catalog export with the price book.
+func (h *handler) importCatalog(w http.ResponseWriter, r *http.Request) {
+	modules, err := catalog.ParseGatewayExport(http.MaxBytesReader(w, r.Body, maxCatalogBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("catalog export exceeds %d bytes", maxCatalogBytes))
+		return
+	}
+	if err != nil {
 		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.respondJSON(w, http.StatusOK, h.catalog.Import(modules))
+}
+
+// canonical resolves gateway catalog IDs in a decoded selection when the
+// catalog resolver is configured.
+func (h *handler) canonical(sel pricing.Selection) pricing.Selection {
+	if h.catalog == nil {
+		return sel
+	}
+	return h.catalog.Canonical(sel)
+}
+
+func (h *handler) resolveCatalog(w http.ResponseWriter, r *http.Request) {
+	item, ok := h.catalog.Resolve(chi.URLParam(r, "id"))
+	if !ok {
+		h.respondError(w, http.StatusNotFound, "unknown catalog id")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, item)
+}
+
+// expandBOM returns the bill of materials for a selection. Selections the
+// rules cannot build, such as unknown layouts, are 422.
+func (h *handler) expandBOM(w http.ResponseWriter, r *http.Request) {
+	var payload pricing.Selection
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	payload = h.canonical(payload)
+	bill, err := h.bom.Expand(payload)
+	switch {
+	case errors.Is(err, bom.ErrUnsupported):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.respondJSON(w, http.StatusOK, bill)
+}
+
+// respondGateError maps rules gate outcomes: blocked selections are 422 with
+// the violations, an unreachable rules service that fails closed is 503 and
+// a request the rules service rejected is 400. Anything else is an internal
+// failure and is reported without its details.
+func (h *handler) respondGateError(w http.ResponseWriter, check gate.Check, err error) {
+	var rejected *rulesclient.Error
+	switch {
+	case errors.Is(err, gate.ErrBlocked):
+		h.respondJSON(w, http.StatusUnprocessableEntity, map[string]any{"error": err.Error(), "rules": check})
+	case errors.Is(err, gate.ErrUnavailable):
+		h.log.Error().Err(err).Msg("rules check unavailable")
+		h.respondError(w, http.StatusServiceUnavailable, "rules check unavailable")
+	case errors.As(err, &rejected):
+		h.respondError(w, http.StatusBadRequest, rejected.Message)
+	default:
+		h.log.Error().Err(err).Msg("pricing request failed")
+		h.respondError(w, http.StatusInternalServerError, "pricing request failed")
+	}
+}
+
+func (h *handler) evaluate(w http.ResponseWriter, r *http.Request) {
+	var payload pricing.Selection
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	payload = h.canonical(payload)
+	if payload.ConfigurationID == "" {
+		payload.ConfigurationID = uuid.NewString()
+	}
+	payload.Channel = ""
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	result, err := h.evaluator.Evaluate(ctx, payload)
+	if err != nil {
+		h.respondEvaluateError(w, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.log.Error().Err(err).Msg("failed to encode response")
+	}
+}
+
+// respondEvaluateError maps evaluation failures: no rules verdict is 502,
+// a rejected selection is 400.
+func (h *handler) respondEvaluateError(w http.ResponseWriter, err error) {
+	var rejected *rulesclient.Error
+	switch {
+	case errors.Is(err, rulesclient.ErrUnavailable):
+		h.log.Error().Err(err).Msg("evaluate: rules unavailable")
+		h.respondError(w, http.StatusBadGateway, "rules service unavailable")
+	case errors.As(err, &rejected):
+		h.respondError(w, http.StatusBadRequest, rejected.Message)
+	default:
+		h.log.Warn().Err(err).Msg("evaluate failed")
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+func (h *handler) createShare(w http.ResponseWriter, r *http.Request) {
+	var payload pricing.Selection
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	payload = h.canonical(payload)
+	s, err := h.shares.Create(r.Context(), payload)
+	switch {
+	case errors.Is(err, share.ErrInvalid):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.log.Error().Err(err).Msg("create share failed")
+		h.respondError(w, http.StatusInternalServerError, "create share failed")
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, s)
+}
+
+// resolveShare reopens a shared configuration, re-validated and re-priced
+// for ?locale.
+func (h *handler) resolveShare(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	resolved, err := h.shares.Resolve(ctx, chi.URLParam(r, "code"), r.URL.Query().Get("locale"))
+	switch {
+	case errors.Is(err, share.ErrInvalid):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, share.ErrNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		h.respondEvaluateError(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resolved)
+}
+
+func (h *handler) respondJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
 		h.log.Error().Err(err).Msg("failed to encode response")
 	}
 }
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/catalogimport"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/configstore"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quote"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/quotepdf"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/share"
+)
+
+func TestEnforcedGateIgnoresTheClientsChannel(t *testing.T) {
+	// The fake rules service lets everything through for the admin channel,
+	// the way a lax channel policy would.
+	rules := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sel configurator.Selection
+		_ = json.NewDecoder(r.Body).Decode(&sel)
+		lax := sel.Channel == "admin" || r.Header.Get("X-Channel") == "admin"
+		_ = json.NewEncoder(w).Encode(rulesclient.Result{
+			Blocking:   !lax,
+			Violations: []rulesclient.Violation{{Code: "layout.island-counter", Severity: "error"}},
+		})
+	}))
+	defer rules.Close()
+
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{"island-counter": 1200}), nil, 0)
+	g := gate.New(rulesclient.New(rules.URL, nil), gate.Policy{Mode: gate.ModeEnforce})
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Gate: g})
+
+	body := `{"module":"galley","layout":"linear","finish":"matte","currency":"USD","channel":"admin","options":[{"id":"island-counter","quantity":1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/pricing/estimate", strings.NewReader(body))
+	req.Header.Set("X-Channel", "admin")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected the blocked selection to be refused despite channel=admin, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestRevisionsRecordTheAuthenticatedAuthor(t *testing.T) {
+	keys, err := auth.ParseKeys("alice=alice-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svc := pricing.NewService(pricing.NewMatrix(nil, nil), nil, 0)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Configurations: configstore.NewStore(configstore.NewMemoryBackend()), AuthorKeys: keys})
+	body := `{"author":"mallory","selection":{"module":"galley","layout":"linear","finish":"matte","currency":"USD"}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/configurations", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected an anonymous save to be refused, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/configurations", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer alice-key")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var cfg configstore.Configuration
+	if err := json.NewDecoder(rec.Body).Decode(&cfg); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.Author != "alice" {
+		t.Fatalf("expected the key's author instead of the body's, got %q", cfg.Author)
+	}
+}
+
+// brokenStore fails every write, like an unreachable Redis.
+type brokenStore struct {
+	cache.Cache
+}
+
+func (brokenStore) Set(context.Context, string, string, time.Duration) error {
+	return errors.New("dial tcp 10.0.0.7:6379: connection refused")
+}
+
+func TestQuoteStoreFailuresAreInternalErrors(t *testing.T) {
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, nil), nil, 0)
+	quotes := quote.NewService(svc, gate.New(nil, gate.Policy{Mode: gate.ModeOff}), brokenStore{cache.NewMemoryCache()}, nil, time.Hour)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Quotes: quotes})
+
+	body := `{"module":"galley","layout":"linear","finish":"matte","currency":"USD"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/quotes", strings.NewReader(body)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 for a store failure, got %d: %s", rec.Code, rec.Body)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.7") {
+		t.Fatalf("the response leaks the internal error: %s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/quotes", strings.NewReader(`{"layout":"linear","currency":"usd!"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid selection, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestEstimateResolvesGatewayCatalogIDs(t *testing.T) {
+	matrix := pricing.NewMatrix(map[string]float64{"galley": 5000}, nil)
+	svc := pricing.NewService(matrix, nil, 0)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Catalog: catalogimport.NewResolver(catalog.DefaultAliases(), matrix)})
+
+	total := func(module, finish string) float64 {
+		t.Helper()
+		body := `{"module":"` + module + `","layout":"linear","finish":"` + finish + `","currency":"USD"}`
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/estimate", strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s/%s: unexpected status %d: %s", module, finish, rec.Code, rec.Body)
+		}
+		var out pricing.EstimateResponse
+		if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return out.Total
+	}
+	if gateway, native := total("mod-galley-s", "finish-graphite"), total("galley", "matte"); gateway != native {
+		t.Fatalf("expected the gateway IDs to price like galley/matte, got %v and %v", gateway, native)
+	}
+}
+
+func TestCatalogImportLimitsTheBody(t *testing.T) {
+	matrix := pricing.NewMatrix(map[string]float64{"galley": 5000}, nil)
+	svc := pricing.NewService(matrix, nil, 0)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Catalog: catalogimport.NewResolver(catalog.DefaultAliases(), matrix)})
+
+	body := `[{"id":"mod-galley-s","name":"` + strings.Repeat("x", maxCatalogBytes) + `"}]`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/catalog/import", strings.NewReader(body)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for an oversized export, got %d", rec.Code)
+	}
+}
+
+// countingStore counts writes so tests can tell reads from mutations.
+type countingStore struct {
+	cache.Cache
+	sets *int
+}
+
+func (s countingStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	*s.sets++
+	return s.Cache.Set(ctx, key, value, ttl)
+}
+
+func TestPDFRoutesRenderDocuments(t *testing.T) {
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, nil), nil, 0)
+	var sets int
+	store := countingStore{cache.NewMemoryCache(), &sets}
+	shares := share.NewService(nil, store, share.Config{TTL: time.Hour, BaseURL: "https://kitchens.example/s/"})
+	docs, err := quotepdf.New(quotepdf.Config{Brand: "Test Kitchens"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quotes := quote.NewService(svc, gate.New(nil, gate.Policy{Mode: gate.ModeOff}), store, shares, time.Hour)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Quotes: quotes, Shares: shares, Documents: docs})
+
+	body := `{"module":"galley","layout":"linear","finish":"matte","currency":"USD"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/quotes", strings.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var q quote.Quote
+	if err := json.NewDecoder(rec.Body).Decode(&q); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if q.ShareCode == "" || q.ShareURL != "https://kitchens.example/s/"+q.ShareCode {
+		t.Fatalf("expected the quote to carry its share code, got %q %q", q.ShareCode, q.ShareURL)
+	}
+
+	written := sets
+	for i := 0; i < 2; i++ {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/pricing/quotes/"+q.ID+".pdf", nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" {
+			t.Fatalf("expected a quote PDF, got %d %q: %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body)
+		}
+		if !strings.HasPrefix(rec.Body.String(), "%PDF-") {
+			t.Fatalf("expected a PDF body, got %q", rec.Body.String()[:min(rec.Body.Len(), 16)])
+		}
+	}
+	if sets != written {
+		t.Fatalf("expected downloading the quote PDF not to write, got %d writes", sets-written)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/pricing/quotes/unknown.pdf", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown quote PDF, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/estimate.pdf", strings.NewReader(body)))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("expected an estimate PDF, got %d %q: %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body)
+	}
+}
+
+func TestBatchExportAbortsWhenItRunsOutOfTime(t *testing.T) {
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, nil), nil, 0)
+	quotes := quote.NewService(svc, gate.New(nil, gate.Policy{Mode: gate.ModeOff}), cache.NewMemoryCache(), nil, time.Hour)
+	h := NewHTTPHandler(zerolog.Nop(), svc, Options{Quotes: quotes})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/pricing/quotes/export", strings.NewReader(`{"ids":["a","b"]}`)))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "missing") {
+		t.Fatalf("expected missing rows for unknown quotes, got %d: %s", rec.Code, rec.Body)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/v1/pricing/quotes/export", strings.NewReader(`{"ids":["a","b"]}`)).WithContext(ctx)
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Fatalf("expected the export to abort the response, got %v", p)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), req)
+}
@@ -1,6 +1,16 @@
 package pricing
 
-import "sync"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+)
 
 // Matrix hosts deterministic price tables and multipliers. All reads are
 // guarded by a RWMutex so hot paths can scale across goroutines safely.
@@ -10,6 +20,9 @@ type Matrix struct {
 	optionAdders     map[string]float64
 	layoutMultiplier map[string]float64
 	finishMultiplier map[string]float64
+	finishLeadWeeks  map[string]int
+	// version memoizes Version until the next price change.
+	version string
 }
 
 // NewMatrix builds a new pricing matrix using the provided seeds. Missing maps
@@ -20,6 +33,7 @@ func NewMatrix(base map[string]float64, options map[string]float64) *Matrix {
 		optionAdders:     make(map[string]float64),
 		layoutMultiplier: defaultLayoutMultipliers(),
 		finishMultiplier: defaultFinishMultipliers(),
+		finishLeadWeeks:  defaultFinishLeadTimes(),
 	}
 
 	for k, v := range base {
@@ -50,6 +64,19 @@ func defaultFinishMultipliers() map[string]float64 {
 	}
 }
 
+// minLeadTimeWeeks is the shortest production slot we quote, matching the
+// gateway's quote floor.
+const minLeadTimeWeeks = 8
+
+func defaultFinishLeadTimes() map[string]int {
+	return map[string]int{
+		"matte":      8,
+		"gloss":      9,
+		"stainless":  12,
+		"wood-grain": 10,
+	}
+}
+
 func (m *Matrix) ModuleBase(module string) float64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -86,9 +113,111 @@ func (m *Matrix) FinishMultiplier(finish string) float64 {
 	return 1.0
 }
 
+// LeadTimeWeeks returns the production lead time for a finish, never below
+// the quoting floor.
+func (m *Matrix) LeadTimeWeeks(finish string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if v, ok := m.finishLeadWeeks[finish]; ok && v > minLeadTimeWeeks {
+		return v
+	}
+	return minLeadTimeWeeks
+}
+
+// Modules returns the modules with an explicit base price, sorted.
+func (m *Matrix) Modules() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.moduleBase)
+}
+
+// Finishes returns the finishes with an explicit multiplier, sorted.
+func (m *Matrix) Finishes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.finishMultiplier)
+}
+
+// HasModule reports whether the module has an explicit base price rather
+// than the fallback.
+func (m *Matrix) HasModule(module string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.moduleBase[module]
+	return ok
+}
+
+// HasFinish reports whether the finish has an explicit multiplier.
+func (m *Matrix) HasFinish(finish string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.finishMultiplier[finish]
+	return ok
+}
+
+func sortedKeys(table map[string]float64) []string {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CheckCatalog reports options the catalog offers without a price and prices
+// for options the catalog does not know, so the two tables cannot drift.
+func (m *Matrix) CheckCatalog(options *catalog.Catalog) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var unpriced, unknown []string
+	for _, id := range options.IDs() {
+		if _, ok := m.optionAdders[id]; !ok {
+			unpriced = append(unpriced, id)
+		}
+	}
+	for _, id := range sortedKeys(m.optionAdders) {
+		if _, ok := options.Lookup(id); !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	var problems []string
+	if len(unpriced) > 0 {
+		problems = append(problems, "no price for "+strings.Join(unpriced, ", "))
+	}
+	if len(unknown) > 0 {
+		problems = append(problems, "priced options missing from the catalog: "+strings.Join(unknown, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("option prices do not match the catalog: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 // UpdateOption allows background sync jobs to atomically tweak option adders.
 func (m *Matrix) UpdateOption(id string, price float64) {
 	m.mu.Lock()
 	m.optionAdders[id] = price
+	m.version = ""
 	m.mu.Unlock()
 }
+
+// Version identifies the current price book contents: any price, multiplier
+// or lead time change yields a new version, so results cached under the old
+// one are never served.
+func (m *Matrix) Version() string {
+	m.mu.RLock()
+	v := m.version
+	m.mu.RUnlock()
+	if v != "" {
+		return v
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.version == "" {
+		// Maps marshal with sorted keys, so equal tables hash equally.
+		payload, _ := json.Marshal([]any{m.moduleBase, m.optionAdders, m.layoutMultiplier, m.finishMultiplier, m.finishLeadWeeks})
+		sum := sha256.Sum256(payload)
+		m.version = hex.EncodeToString(sum[:8])
+	}
+	return m.version
+}
@@ -8,18 +8,26 @@ import (
 	"time"
 
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
 )
 
 // Service exposes concurrency-safe price estimation.
 type Service struct {
-	matrix *Matrix
-	cache  cache.Cache
-	ttl    time.Duration
+	matrix  *Matrix
+	catalog *catalog.Catalog
+	cache   cache.Cache
+	ttl     time.Duration
 }
 
-// NewService wires dependencies and returns a ready-to-use Service.
+// NewService wires dependencies and returns a ready-to-use Service backed by
+// the shared option catalog.
 func NewService(matrix *Matrix, cache cache.Cache, ttl time.Duration) *Service {
-	return &Service{matrix: matrix, cache: cache, ttl: ttl}
+	return NewServiceWithCatalog(matrix, catalog.Default(), cache, ttl)
+}
+
+// NewServiceWithCatalog groups option line items using the given catalog.
+func NewServiceWithCatalog(matrix *Matrix, options *catalog.Catalog, cache cache.Cache, ttl time.Duration) *Service {
+	return &Service{matrix: matrix, catalog: options, cache: cache, ttl: ttl}
 }
 
 // Estimate computes totals with O(n) cost where n is the number of options.
@@ -27,7 +35,7 @@ func (s *Service) Estimate(ctx context.Context, sel Selection) (EstimateResponse
 	if sel.Currency == "" {
 		sel.Currency = "USD"
 	}
-	if err := sel.Validate(); err != nil {
+	if err := validateSelection(sel); err != nil {
 		return EstimateResponse{}, err
 	}
 
@@ -42,12 +50,28 @@ func (s *Service) Estimate(ctx context.Context, sel Selection) (EstimateResponse
 	}
 
 	subtotal := s.matrix.ModuleBase(sel.Module)
+	lines := make([]LineItem, 0)
+	lineIndex := make(map[string]int)
 	for _, opt := range sel.Options {
 		qty := opt.Quantity
 		if qty <= 0 {
 			qty = 1
 		}
-		subtotal += s.matrix.OptionAdder(opt.ID) * float64(qty)
+		amount := s.matrix.OptionAdder(opt.ID) * float64(qty)
+		subtotal += amount
+
+		category, name := s.describe(opt.ID)
+		i, ok := lineIndex[category]
+		if !ok {
+			i = len(lines)
+			lineIndex[category] = i
+			lines = append(lines, LineItem{Category: category, Name: name, Options: make([]LineOption, 0, 1)})
+		}
+		lines[i].Options = append(lines[i].Options, LineOption{ID: opt.ID, Quantity: qty, Amount: round(amount)})
+		lines[i].Amount += amount
+	}
+	for i := range lines {
+		lines[i].Amount = round(lines[i].Amount)
 	}
 
 	adjustments := make([]EstimateAdjustment, 0, 3)
@@ -88,24 +112,42 @@ func (s *Service) Estimate(ctx context.Context, sel Selection) (EstimateResponse
 
 	resp := EstimateResponse{
 		ConfigurationID: sel.ConfigurationID,
+		Fingerprint:     sel.Fingerprint(),
 		Currency:        sel.Currency,
 		Subtotal:        round(subtotal),
+		Lines:           lines,
 		Adjustments:     adjustments,
 		Total:           round(total),
+		LeadTimeWeeks:   s.matrix.LeadTimeWeeks(sel.Finish),
 		LatencyMicros:   time.Since(start).Microseconds(),
 	}
 
 	if s.cache != nil && s.ttl > 0 {
 		if payload, err := json.Marshal(resp); err == nil {
-			_ = s.cache.Set(ctx, sel.cacheKey(), string(payload), s.ttl)
+			_ = s.cache.Set(ctx, s.cacheKey(sel), string(payload), s.ttl)
 		}
 	}
 
 	return resp, nil
 }
 
+// describe returns the catalog category and display name for an option.
+// Options missing from the catalog are grouped under "other".
+func (s *Service) describe(optionID string) (string, string) {
+	if opt, ok := s.catalog.Lookup(optionID); ok {
+		return opt.Category, s.catalog.CategoryName(opt.Category)
+	}
+	return OtherCategory, "Other"
+}
+
+// cacheKey namespaces cached estimates and scopes them to the price book
+// version, so a price change never serves totals computed before it.
+func (s *Service) cacheKey(sel Selection) string {
+	return "pricing:estimate:" + s.matrix.Version() + ":" + sel.Fingerprint()
+}
+
 func (s *Service) readFromCache(ctx context.Context, sel Selection) (EstimateResponse, bool) {
-	raw, err := s.cache.Get(ctx, sel.cacheKey())
+	raw, err := s.cache.Get(ctx, s.cacheKey(sel))
 	if err != nil {
 		return EstimateResponse{}, false
 	}
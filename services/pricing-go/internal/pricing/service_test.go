@@ -2,10 +2,12 @@ package pricing
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
 )
 
 func TestEstimateProducesDeterministicTotals(t *testing.T) {
@@ -29,7 +31,7 @@ func TestEstimateProducesDeterministicTotals(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	expectedSubtotal := 5000 + 1200 + 3*200
+	expectedSubtotal := float64(5000 + 1200 + 3*200)
 	if resp.Subtotal != expectedSubtotal {
 		t.Fatalf("unexpected subtotal: got %v want %v", resp.Subtotal, expectedSubtotal)
 	}
@@ -39,6 +41,9 @@ func TestEstimateProducesDeterministicTotals(t *testing.T) {
 	if resp.Cached {
 		t.Fatalf("first call should not be cached")
 	}
+	if resp.Fingerprint != selection.Fingerprint() {
+		t.Fatalf("expected the configuration fingerprint, got %q", resp.Fingerprint)
+	}
 
 	resp2, err := svc.Estimate(context.Background(), selection)
 	if err != nil {
@@ -75,7 +80,118 @@ func TestSelectionCacheKeyIgnoresOrder(t *testing.T) {
 		},
 	}
 
-	if selectionA.cacheKey() != selectionB.cacheKey() {
+	if selectionA.Fingerprint() != selectionB.Fingerprint() {
 		t.Fatalf("expected cache keys to match regardless of option order")
 	}
 }
+
+func TestPriceChangesBypassCachedEstimates(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	matrix := NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{"island-counter": 1200})
+	svc := NewService(matrix, store, time.Minute)
+	selection := Selection{Module: "galley", Layout: "linear", Finish: "matte", Currency: "USD", Options: []SelectionOption{{ID: "island-counter", Quantity: 1}}}
+
+	before, err := svc.Estimate(ctx, selection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get(ctx, "pricing:estimate:"+matrix.Version()+":"+selection.Fingerprint()); err != nil {
+		t.Fatalf("expected the estimate cached under the pricing namespace: %v", err)
+	}
+
+	version := matrix.Version()
+	matrix.UpdateOption("island-counter", 1500)
+	if matrix.Version() == version {
+		t.Fatalf("expected a price change to bump the price book version")
+	}
+	after, err := svc.Estimate(ctx, selection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if after.Cached || after.Subtotal != before.Subtotal+300 {
+		t.Fatalf("expected a fresh estimate at the new price, got %+v", after)
+	}
+}
+
+func TestEstimateGroupsOptionsByCategory(t *testing.T) {
+	matrix := NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{
+		"range-upgrade":     2100,
+		"cooktop-induction": 980,
+		"drawer-lighting":   240,
+	})
+	svc := NewService(matrix, nil, 0)
+
+	resp, err := svc.Estimate(context.Background(), Selection{
+		Module:   "galley",
+		Currency: "USD",
+		Options: []SelectionOption{
+			{ID: "range-upgrade", Quantity: 1},
+			{ID: "drawer-lighting", Quantity: 2},
+			{ID: "cooktop-induction", Quantity: 1},
+			{ID: "mystery", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Lines) != 3 {
+		t.Fatalf("expected 3 category lines, got %+v", resp.Lines)
+	}
+	appliances := resp.Lines[0]
+	if appliances.Category != "appliance" || appliances.Name != "Appliances" || appliances.Amount != 3080 || len(appliances.Options) != 2 {
+		t.Fatalf("unexpected appliance line %+v", appliances)
+	}
+	if resp.Lines[1].Category != "lighting" || resp.Lines[1].Amount != 480 {
+		t.Fatalf("unexpected lighting line %+v", resp.Lines[1])
+	}
+	if resp.Lines[2].Category != OtherCategory {
+		t.Fatalf("uncatalogued options should be grouped as other, got %+v", resp.Lines[2])
+	}
+}
+
+func TestTaxRates(t *testing.T) {
+	rates, err := ParseTaxRates([]string{"GB=0.20", "en-DE=0.19", "us=0"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if rates["gb"] != 0.2 || rates["de"] != 0.19 || rates["us"] != 0 {
+		t.Fatalf("unexpected rates %v", rates)
+	}
+	for _, bad := range []string{"gb", "gb=abc", "gb=1.5", "=0.1"} {
+		if _, err := ParseTaxRates([]string{bad}); err == nil {
+			t.Fatalf("expected an error for %q", bad)
+		}
+	}
+
+	taxes := rates.Taxes("en-GB", 1000.05)
+	if len(taxes) != 1 || taxes[0].Market != "GB" || taxes[0].Amount != 200.01 || TaxedTotal(1000.05, taxes) != 1200.06 {
+		t.Fatalf("unexpected GB taxes %+v", taxes)
+	}
+	if rates.Taxes("us", 1000) != nil || rates.Taxes("", 1000) != nil {
+		t.Fatal("untaxed markets should have no tax lines")
+	}
+}
+
+func TestCheckCatalogFindsDriftBetweenPricesAndOptions(t *testing.T) {
+	options := catalog.Default()
+	prices := make(map[string]float64)
+	for _, id := range options.IDs() {
+		prices[id] = 100
+	}
+	if err := NewMatrix(nil, prices).CheckCatalog(options); err != nil {
+		t.Fatalf("expected a complete price table to pass, got %v", err)
+	}
+
+	delete(prices, "island-counter")
+	prices["hover-shelf"] = 50
+	err := NewMatrix(nil, prices).CheckCatalog(options)
+	if err == nil {
+		t.Fatalf("expected drift to be reported")
+	}
+	for _, id := range []string{"island-counter", "hover-shelf"} {
+		if !strings.Contains(err.Error(), id) {
+			t.Fatalf("expected %s in %q", id, err)
+		}
+	}
+}
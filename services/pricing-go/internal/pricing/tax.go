@@ -0,0 +1,64 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+// TaxRates maps normalized market codes to the rate charged on an
+// estimate's total. Markets without a rate are untaxed.
+type TaxRates map[string]float64
+
+// TaxLine is the tax due on one estimate.
+type TaxLine struct {
+	Label  string  `json:"label"`
+	Market string  `json:"market"`
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+}
+
+// ParseTaxRates reads "market=rate" pairs such as "gb=0.20". Markets may be
+// given as codes or locales.
+func ParseTaxRates(pairs []string) (TaxRates, error) {
+	rates := make(TaxRates, len(pairs))
+	for _, pair := range pairs {
+		market, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(market) == "" {
+			return nil, fmt.Errorf("tax rate %q: want market=rate", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || rate >= 1 {
+			return nil, fmt.Errorf("tax rate %q: rate must be a fraction in [0, 1)", pair)
+		}
+		rates[configurator.NormalizeMarket(market)] = rate
+	}
+	return rates, nil
+}
+
+// Taxes returns the tax lines for an estimate priced for market, rounded to
+// the cent. Untaxed markets get none.
+func (t TaxRates) Taxes(market string, total float64) []TaxLine {
+	market = configurator.NormalizeMarket(market)
+	rate := t[market]
+	if rate <= 0 {
+		return nil
+	}
+	return []TaxLine{{
+		Label:  "Tax",
+		Market: strings.ToUpper(market),
+		Rate:   rate,
+		Amount: math.Round(total*rate*100) / 100,
+	}}
+}
+
+// TaxedTotal adds the tax lines to total.
+func TaxedTotal(total float64, taxes []TaxLine) float64 {
+	for _, t := range taxes {
+		total += t.Amount
+	}
+	return math.Round(total*100) / 100
+}
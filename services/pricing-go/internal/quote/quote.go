@@ -0,0 +1,130 @@
+// Package quote issues persistent, rules-checked quotes. A quote freezes the
+// selection, its estimate and the rules verdict at the time it was issued.
+package quote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/share"
+)
+
+var (
+	// ErrNotFound is returned for unknown or expired quotes.
+	ErrNotFound = errors.New("quote not found")
+	// ErrInvalid wraps selections that cannot be priced.
+	ErrInvalid = errors.New("invalid quote request")
+)
+
+const keyPrefix = "pricing:quote:"
+
+// Quote is an issued price commitment.
+type Quote struct {
+	ID              string                   `json:"id"`
+	ConfigurationID string                   `json:"configurationId"`
+	Selection       configurator.Selection   `json:"selection"`
+	Estimate        pricing.EstimateResponse `json:"estimate"`
+	Rules           gate.Check               `json:"rules"`
+	CreatedAt       time.Time                `json:"createdAt"`
+	ValidUntil      time.Time                `json:"validUntil"`
+	ShareCode       string                   `json:"shareCode,omitempty"`
+	ShareURL        string                   `json:"shareUrl,omitempty"`
+}
+
+// Sharer issues share codes for quoted selections.
+type Sharer interface {
+	Create(ctx context.Context, sel configurator.Selection) (share.Share, error)
+}
+
+// Service prices, checks and stores quotes. Quotes live in the cache until
+// they expire.
+type Service struct {
+	pricing  *pricing.Service
+	gate     *gate.Gate
+	store    cache.Cache
+	shares   Sharer
+	validity time.Duration
+	now      func() time.Time
+}
+
+// NewService wires pricing, the rules gate, the quote store and, when shares
+// is not nil, the share codes issued with each quote. Quotes stay valid, and
+// retrievable, for validity.
+func NewService(svc *pricing.Service, g *gate.Gate, store cache.Cache, shares Sharer, validity time.Duration) *Service {
+	return &Service{pricing: svc, gate: g, store: store, shares: shares, validity: validity, now: time.Now}
+}
+
+// Create checks the selection against rules, prices it and stores the quote.
+// With gate.ErrBlocked the returned quote still carries the rules check so
+// callers can show why.
+func (s *Service) Create(ctx context.Context, sel configurator.Selection) (Quote, error) {
+	if sel.ConfigurationID == "" {
+		sel.ConfigurationID = uuid.NewString()
+	}
+	if sel.Currency == "" {
+		sel.Currency = "USD"
+	}
+	check, err := s.gate.Check(ctx, sel, gate.OpQuote)
+	if err != nil {
+		return Quote{Rules: check}, err
+	}
+	estimate, err := s.pricing.Estimate(ctx, sel)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	// Quotes are fresh commitments, not cache hits.
+	estimate.Cached = false
+
+	now := s.now().UTC()
+	q := Quote{
+		ID:              uuid.NewString(),
+		ConfigurationID: sel.ConfigurationID,
+		Selection:       sel,
+		Estimate:        estimate,
+		Rules:           check,
+		CreatedAt:       now,
+		ValidUntil:      now.Add(s.validity),
+	}
+	// The share code is issued once here so reading the quote, or its PDF,
+	// never writes to the share store.
+	if s.shares != nil {
+		sh, err := s.shares.Create(ctx, sel)
+		if err != nil {
+			return Quote{}, fmt.Errorf("share quote: %w", err)
+		}
+		q.ShareCode, q.ShareURL = sh.Code, sh.URL
+	}
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return Quote{}, err
+	}
+	if err := s.store.Set(ctx, keyPrefix+q.ID, string(payload), s.validity); err != nil {
+		return Quote{}, fmt.Errorf("store quote: %w", err)
+	}
+	return q, nil
+}
+
+// Get loads an issued quote.
+func (s *Service) Get(ctx context.Context, id string) (Quote, error) {
+	raw, err := s.store.Get(ctx, keyPrefix+id)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return Quote{}, ErrNotFound
+	}
+	if err != nil {
+		return Quote{}, err
+	}
+	var q Quote
+	if err := json.Unmarshal([]byte(raw), &q); err != nil {
+		return Quote{}, fmt.Errorf("decode quote %s: %w", id, err)
+	}
+	return q, nil
+}
@@ -0,0 +1,70 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/gate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+type fakeRules struct {
+	res rulesclient.Result
+}
+
+func (f fakeRules) Validate(context.Context, configurator.Selection) (rulesclient.Result, error) {
+	return f.res, nil
+}
+
+func newService(res rulesclient.Result, mode gate.Mode) *Service {
+	svc := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, nil), cache.NewMemoryCache(), time.Minute)
+	g := gate.New(fakeRules{res: res}, gate.Policy{Mode: mode})
+	return NewService(svc, g, cache.NewMemoryCache(), nil, 24*time.Hour)
+}
+
+var islandCounter = configurator.Selection{
+	Module:  "galley",
+	Layout:  "linear",
+	Finish:  "matte",
+	Options: []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}},
+}
+
+var blocked = rulesclient.Result{
+	Blocking:   true,
+	Violations: []rulesclient.Violation{{Code: "layout.island-counter", Severity: "error"}},
+}
+
+func TestCreateRefusesBlockedSelection(t *testing.T) {
+	q, err := newService(blocked, gate.ModeEnforce).Create(context.Background(), islandCounter)
+	if !errors.Is(err, gate.ErrBlocked) || len(q.Rules.Violations) != 1 || q.ID != "" {
+		t.Fatalf("expected a refused quote with violations, got %+v %v", q, err)
+	}
+}
+
+func TestCreateStoresWarnedQuote(t *testing.T) {
+	svc := newService(blocked, gate.ModeWarn)
+	q, err := svc.Create(context.Background(), islandCounter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !q.Rules.Blocking || q.Estimate.Currency != "USD" || !q.ValidUntil.Equal(q.CreatedAt.Add(24*time.Hour)) {
+		t.Fatalf("unexpected quote %+v", q)
+	}
+
+	loaded, err := svc.Get(context.Background(), q.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Estimate.Total != q.Estimate.Total || len(loaded.Rules.Violations) != 1 {
+		t.Fatalf("stored quote should embed its estimate and violations, got %+v", loaded)
+	}
+	if _, err := svc.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
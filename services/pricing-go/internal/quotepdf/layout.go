@@ -0,0 +1,157 @@
+package quotepdf
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrDirective is returned for template output the layout does not
+// understand.
+var ErrDirective = errors.New("unknown layout directive")
+
+// Page geometry and palette.
+const (
+	margin       = 50.0
+	footerHeight = 30.0
+	bandHeight   = 90.0
+)
+
+var (
+	ink    = rgb{0.13, 0.13, 0.15}
+	muted  = rgb{0.45, 0.45, 0.5}
+	accent = rgb{0.11, 0.27, 0.33}
+	paper  = rgb{1, 1, 1}
+	hair   = rgb{0.8, 0.8, 0.82}
+	shade  = rgb{0.93, 0.95, 0.96}
+)
+
+// layout draws the template's directive lines onto pages, breaking pages
+// as needed, and numbers the pages once the count is known.
+func layout(script io.Reader) ([]*canvas, error) {
+	var (
+		pages []*canvas
+		page  *canvas
+		y     float64
+		brand string
+	)
+	right := pageWidth - margin
+	newPage := func() {
+		page = &canvas{}
+		pages = append(pages, page)
+		y = pageHeight - margin
+		if len(pages) > 1 && brand != "" {
+			page.text(fontBold, 9, margin, y, accent, brand)
+			y -= 24
+		}
+	}
+	need := func(h float64) {
+		if y-h < margin+footerHeight {
+			newPage()
+		}
+	}
+	pair := func(font string, size, indent float64, color rgb, label, value string) {
+		need(size + 6)
+		y -= size + 6
+		page.text(font, size, margin+indent, y, color, label)
+		page.text(font, size, right-textWidth(font, size, value), y, color, value)
+	}
+	newPage()
+
+	sc := bufio.NewScanner(script)
+	for n := 1; sc.Scan(); n++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		parts := strings.Split(sc.Text(), "\t")
+		arg := func(i int) string {
+			if i < len(parts) {
+				return strings.TrimSpace(parts[i])
+			}
+			return ""
+		}
+		switch parts[0] {
+		case "header":
+			brand = arg(1)
+			page.rect(0, pageHeight-bandHeight, pageWidth, bandHeight, accent)
+			page.text(fontBold, 22, margin, pageHeight-52, paper, brand)
+			if sub := strings.ToUpper(arg(2)); sub != "" {
+				page.text(fontBold, 12, right-textWidth(fontBold, 12, sub), pageHeight-50, paper, sub)
+			}
+			y = pageHeight - bandHeight - 14
+		case "title":
+			need(30)
+			y -= 26
+			page.text(fontBold, 18, margin, y, ink, arg(1))
+		case "section":
+			need(40)
+			y -= 18
+			page.text(fontBold, 12, margin, y, accent, arg(1))
+			y -= 4
+			page.line(margin, y, right, y, 0.75, accent)
+		case "text", "muted":
+			color, size := ink, 10.0
+			if parts[0] == "muted" {
+				color, size = muted, 9
+			}
+			for _, l := range wrap(arg(1), fontRegular, size, right-margin) {
+				need(size + 4)
+				y -= size + 4
+				page.text(fontRegular, size, margin, y, color, l)
+			}
+		case "row":
+			pair(fontRegular, 10, 0, ink, arg(1), arg(2))
+		case "item":
+			pair(fontRegular, 9, 14, muted, arg(1), arg(2))
+		case "total":
+			pair(fontBold, 10, 0, ink, arg(1), arg(2))
+		case "grand":
+			need(28)
+			y -= 22
+			page.rect(margin-6, y-6, right-margin+12, 22, shade)
+			page.text(fontBold, 13, margin, y, accent, arg(1))
+			page.text(fontBold, 13, right-textWidth(fontBold, 13, arg(2)), y, accent, arg(2))
+			y -= 6
+		case "rule":
+			need(10)
+			y -= 6
+			page.line(margin, y, right, y, 0.5, hair)
+		case "space":
+			y -= 10
+		default:
+			return nil, fmt.Errorf("%w %q on line %d", ErrDirective, parts[0], n)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, p := range pages {
+		label := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		p.line(margin, margin+footerHeight-10, right, margin+footerHeight-10, 0.5, hair)
+		p.text(fontRegular, 8, margin, margin, muted, brand)
+		p.text(fontRegular, 8, right-textWidth(fontRegular, 8, label), margin, muted, label)
+	}
+	return pages, nil
+}
+
+// wrap breaks s into lines no wider than width points.
+func wrap(s, font string, size, width float64) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, 1)
+	current := words[0]
+	for _, word := range words[1:] {
+		if textWidth(font, size, current+" "+word) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, current)
+}
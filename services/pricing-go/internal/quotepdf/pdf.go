@@ -0,0 +1,177 @@
+package quotepdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A4 in PDF points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// Fonts every PDF reader ships; no font data is embedded.
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+type rgb struct{ r, g, b float64 }
+
+// canvas collects the content stream of one page.
+type canvas struct {
+	buf bytes.Buffer
+}
+
+func (c *canvas) text(font string, size, x, y float64, color rgb, s string) {
+	fmt.Fprintf(&c.buf, "BT %s rg /%s %s Tf %s %s Td (%s) Tj ET\n",
+		color, font, num(size), num(x), num(y), escape(winAnsi(s)))
+}
+
+func (c *canvas) rect(x, y, w, h float64, fill rgb) {
+	fmt.Fprintf(&c.buf, "%s rg %s %s %s %s re f\n", fill, num(x), num(y), num(w), num(h))
+}
+
+func (c *canvas) line(x1, y1, x2, y2, width float64, stroke rgb) {
+	fmt.Fprintf(&c.buf, "%s RG %s w %s %s m %s %s l S\n",
+		stroke, num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// String renders the colour as PDF colour operands.
+func (c rgb) String() string {
+	return num(c.r) + " " + num(c.g) + " " + num(c.b)
+}
+
+// writePDF serialises pages into a PDF 1.4 file with the two standard
+// Helvetica fonts.
+func writePDF(w io.Writer, title string, pages []*canvas) error {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-4 are fixed: catalog, page tree, fonts. Pages and their
+	// contents follow in pairs.
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, p := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			num(pageWidth), num(pageHeight), fontRegular, fontBold, 6+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.buf.Len(), p.buf.String()))
+	}
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (pricing-go) >>", escape(winAnsi(title))))
+	info := len(offsets)
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// num formats a number to the hundredth of a point PDF readers resolve.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// escape protects PDF string delimiters.
+func escape(s string) string {
+	var b bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n', '\r':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// winAnsi maps text onto the standard fonts' WinAnsi encoding. Latin-1
+// passes through, a few common typographic characters are mapped and
+// everything else becomes '?'.
+func winAnsi(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			b = append(b, byte(r))
+		case r == '€':
+			b = append(b, 0x80)
+		case r == '–':
+			b = append(b, 0x96)
+		case r == '—':
+			b = append(b, 0x97)
+		case r == '‘', r == '’':
+			b = append(b, '\'')
+		case r == '“', r == '”':
+			b = append(b, '"')
+		case r == '•':
+			b = append(b, 0x95)
+		case r == '…':
+			b = append(b, 0x85)
+		default:
+			b = append(b, '?')
+		}
+	}
+	return string(b)
+}
+
+// Advance widths in 1/1000 em for ASCII 32-126, from the Adobe AFM files.
+var (
+	helveticaWidths = [...]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [...]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// textWidth measures s in points.
+func textWidth(font string, size float64, s string) float64 {
+	widths := helveticaWidths[:]
+	if font == fontBold {
+		widths = helveticaBoldWidths[:]
+	}
+	total := 0
+	for _, c := range []byte(winAnsi(s)) {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
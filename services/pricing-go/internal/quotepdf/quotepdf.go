@@ -0,0 +1,245 @@
+// Package quotepdf renders quotes and estimates as branded PDF documents.
+// Layout is driven by a text/template that emits one drawing directive per
+// line; the PDF itself is written in-process with the standard Helvetica
+// fonts, so no external renderer or font files are needed.
+package quotepdf
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+//go:embed quote.tmpl
+var defaultTemplate string
+
+// Document kinds.
+const (
+	KindQuote    = "Quote"
+	KindEstimate = "Estimate"
+)
+
+// Config brands the documents. Template replaces the embedded layout when
+// set.
+type Config struct {
+	Brand    string
+	TaxRates pricing.TaxRates
+	Template string
+}
+
+// Document is what a PDF is rendered from. ValidUntil is left zero for
+// estimates, which carry no price commitment.
+type Document struct {
+	Kind       string
+	ID         string
+	CreatedAt  time.Time
+	ValidUntil time.Time
+	Selection  configurator.Selection
+	Estimate   pricing.EstimateResponse
+	Warnings   []rulesclient.Violation
+	ShareCode  string
+	ShareURL   string
+}
+
+// Renderer turns documents into PDFs. It is safe for concurrent use.
+type Renderer struct {
+	brand   string
+	taxes   pricing.TaxRates
+	tmpl    *template.Template
+	catalog *catalog.Catalog
+}
+
+// New parses the layout template.
+func New(cfg Config) (*Renderer, error) {
+	src := cfg.Template
+	if src == "" {
+		src = defaultTemplate
+	}
+	tmpl, err := template.New("quote").Funcs(funcs).Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse quote template: %w", err)
+	}
+	brand := cfg.Brand
+	if brand == "" {
+		brand = "Kitchen Configurator"
+	}
+	return &Renderer{brand: brand, taxes: cfg.TaxRates, tmpl: tmpl, catalog: catalog.Default()}, nil
+}
+
+// LoadTemplate reads a layout template from disk.
+func LoadTemplate(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// Render writes doc as a PDF.
+func (r *Renderer) Render(w io.Writer, doc Document) error {
+	v := r.view(doc)
+	var script bytes.Buffer
+	if err := r.tmpl.Execute(&script, v); err != nil {
+		return fmt.Errorf("execute quote template: %w", err)
+	}
+	pages, err := layout(&script)
+	if err != nil {
+		return err
+	}
+	return writePDF(w, strings.TrimSpace(v.Brand+" "+v.Kind+" "+v.Reference), pages)
+}
+
+// view is the data the layout template sees.
+type view struct {
+	Brand           string
+	Kind            string
+	Reference       string
+	ConfigurationID string
+	Issued          time.Time
+	ValidUntil      time.Time
+	Currency        string
+	Summary         summary
+	Lines           []viewLine
+	Subtotal        float64
+	Adjustments     []pricing.EstimateAdjustment
+	Total           float64
+	Taxes           []pricing.TaxLine
+	GrandTotal      float64
+	Notes           []string
+	ShareCode       string
+	ShareURL        string
+	Fingerprint     string
+}
+
+type summary struct {
+	Module        string
+	Layout        string
+	Finish        string
+	Market        string
+	Dimensions    string
+	LeadTimeWeeks int
+}
+
+type viewLine struct {
+	Name    string
+	Amount  float64
+	Options []viewOption
+}
+
+type viewOption struct {
+	Name     string
+	Quantity int
+	Amount   float64
+}
+
+func (r *Renderer) view(doc Document) view {
+	est, sel := doc.Estimate, doc.Selection
+	v := view{
+		Brand:           field(r.brand),
+		Kind:            field(doc.Kind),
+		Reference:       field(doc.ID),
+		ConfigurationID: field(est.ConfigurationID),
+		Issued:          doc.CreatedAt,
+		ValidUntil:      doc.ValidUntil,
+		Currency:        est.Currency,
+		Subtotal:        est.Subtotal,
+		Total:           est.Total,
+		GrandTotal:      est.Total,
+		ShareCode:       field(doc.ShareCode),
+		ShareURL:        field(doc.ShareURL),
+		Fingerprint:     field(est.Fingerprint),
+		Summary: summary{
+			Module:        field(sel.Module),
+			Layout:        field(sel.Layout),
+			Finish:        field(sel.Finish),
+			Market:        strings.ToUpper(field(configurator.NormalizeMarket(sel.Market))),
+			LeadTimeWeeks: est.LeadTimeWeeks,
+		},
+	}
+	if v.Kind == "" {
+		v.Kind = KindEstimate
+	}
+	if d := sel.Dimensions; d.LengthMM > 0 || d.HeightMM > 0 {
+		v.Summary.Dimensions = fmt.Sprintf("%d mm run, %d mm worktop height", d.LengthMM, d.HeightMM)
+	}
+	for _, line := range est.Lines {
+		vl := viewLine{Name: field(line.Name), Amount: line.Amount}
+		for _, opt := range line.Options {
+			name := opt.ID
+			if o, ok := r.catalog.Lookup(opt.ID); ok {
+				name = o.Name
+			}
+			vl.Options = append(vl.Options, viewOption{Name: field(name), Quantity: opt.Quantity, Amount: opt.Amount})
+		}
+		v.Lines = append(v.Lines, vl)
+	}
+	for _, adj := range est.Adjustments {
+		v.Adjustments = append(v.Adjustments, pricing.EstimateAdjustment{Reason: field(adj.Reason), Amount: adj.Amount})
+	}
+	v.Taxes = r.taxes.Taxes(sel.Market, est.Total)
+	v.GrandTotal = pricing.TaxedTotal(est.Total, v.Taxes)
+	for _, w := range doc.Warnings {
+		msg := w.Message
+		if msg == "" {
+			msg = w.Code
+		}
+		v.Notes = append(v.Notes, field(msg))
+	}
+	return v
+}
+
+// field keeps data from breaking the one-directive-per-line format.
+func field(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+var currencySymbols = map[string]string{"USD": "$", "GBP": "£", "EUR": "€"}
+
+var funcs = template.FuncMap{
+	"money":   money,
+	"percent": func(rate float64) string { return strconv.FormatFloat(rate*100, 'f', -1, 64) + "%" },
+	"date":    func(t time.Time) string { return t.UTC().Format("2 January 2006") },
+}
+
+// money formats an amount with thousands separators and the currency
+// symbol, or the ISO code for currencies without one.
+func money(currency string, amount float64) string {
+	currency = strings.ToUpper(currency)
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+	var b strings.Builder
+	if amount < 0 && cents != 0 {
+		b.WriteByte('-')
+	}
+	if sym, ok := currencySymbols[currency]; ok {
+		b.WriteString(sym)
+	} else if currency != "" {
+		b.WriteString(currency + " ")
+	}
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	fmt.Fprintf(&b, ".%02d", cents%100)
+	return b.String()
+}
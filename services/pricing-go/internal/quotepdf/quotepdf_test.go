@@ -0,0 +1,143 @@
+package quotepdf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+func document() Document {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return Document{
+		Kind:       KindQuote,
+		ID:         "q-123",
+		CreatedAt:  created,
+		ValidUntil: created.Add(30 * 24 * time.Hour),
+		Selection: configurator.Selection{
+			ConfigurationID: "cfg-1",
+			Module:          "galley",
+			Layout:          "linear",
+			Finish:          "matte",
+			Market:          "en-GB",
+			Dimensions:      configurator.Dimensions{LengthMM: 3600, HeightMM: 900},
+		},
+		Estimate: pricing.EstimateResponse{
+			ConfigurationID: "cfg-1",
+			Fingerprint:     "v1.abc",
+			Currency:        "GBP",
+			Subtotal:        6200,
+			Lines: []pricing.LineItem{{
+				Category: "worktop",
+				Name:     "Worktops",
+				Amount:   1200,
+				Options:  []pricing.LineOption{{ID: "island-counter", Quantity: 1, Amount: 1200}},
+			}},
+			Adjustments:   []pricing.EstimateAdjustment{{Reason: "finish multiplier", Amount: 0}},
+			Total:         6200,
+			LeadTimeWeeks: 8,
+		},
+		Warnings:  []rulesclient.Violation{{Code: "clearance", Message: "Check the (dishwasher) clearance"}},
+		ShareCode: "abcd-efgh",
+	}
+}
+
+func render(t *testing.T, cfg Config, doc Document) string {
+	t.Helper()
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("new renderer: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, doc); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return buf.String()
+}
+
+func TestRenderQuote(t *testing.T) {
+	out := render(t, Config{Brand: "Parviz Kitchens", TaxRates: pricing.TaxRates{"gb": 0.2}}, document())
+	if !strings.HasPrefix(out, "%PDF-1.4") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("not a PDF file: %q...", out[:20])
+	}
+	for _, want := range []string{
+		"(Parviz Kitchens)",
+		"(Quote q-123)",
+		"Valid until 31 October 2026",
+		"(Island counter \xd7 1)",
+		"(\xa36,200.00)",
+		"(Tax \\(20%\\))",
+		"(\xa31,240.00)",
+		"(\xa37,440.00)",
+		"(abcd-efgh)",
+		"Check the \\(dishwasher\\) clearance",
+		"(3600 mm run, 900 mm worktop height)",
+		"(Page 1 of 1)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output", want)
+		}
+	}
+}
+
+func TestRenderEstimateWithoutTaxOrValidity(t *testing.T) {
+	doc := document()
+	doc.Kind, doc.ID, doc.ValidUntil = KindEstimate, "", time.Time{}
+	out := render(t, Config{TaxRates: pricing.TaxRates{"de": 0.19}}, doc)
+	if strings.Contains(out, "Valid until") || strings.Contains(out, "(Tax ") {
+		t.Fatal("estimates for untaxed markets should carry neither validity nor tax")
+	}
+	if !strings.Contains(out, "(ESTIMATE)") || !strings.Contains(out, "(\xa36,200.00)") {
+		t.Fatal("expected the estimate band and untaxed total")
+	}
+}
+
+func TestRenderPaginatesLongDocuments(t *testing.T) {
+	doc := document()
+	for i := 0; i < 80; i++ {
+		doc.Warnings = append(doc.Warnings, rulesclient.Violation{Message: "warning"})
+	}
+	out := render(t, Config{}, doc)
+	if strings.Contains(out, "/Count 1 ") || !strings.Contains(out, "(Page 2 of ") {
+		t.Fatal("expected a second page")
+	}
+}
+
+func TestTemplateOverride(t *testing.T) {
+	tmpl := "header\t{{.Brand}}\ntitle\tCustom {{.Reference}}\ngrand\tDue\t{{money .Currency .GrandTotal}}\n"
+	out := render(t, Config{Brand: "Acme", Template: tmpl}, document())
+	if !strings.Contains(out, "(Custom q-123)") || !strings.Contains(out, "(Due)") || strings.Contains(out, "(Line items)") {
+		t.Fatal("expected the custom layout")
+	}
+
+	r, err := New(Config{Template: "banner\tnope\n"})
+	if err != nil {
+		t.Fatalf("new renderer: %v", err)
+	}
+	if err := r.Render(&bytes.Buffer{}, document()); !errors.Is(err, ErrDirective) {
+		t.Fatalf("expected ErrDirective, got %v", err)
+	}
+	if _, err := New(Config{Template: "{{.Broken"}); err == nil {
+		t.Fatal("expected a template parse error")
+	}
+}
+
+func TestMoney(t *testing.T) {
+	cases := map[string]string{
+		money("usd", 1234567.891): "$1,234,567.89",
+		money("EUR", -45):         "-€45.00",
+		money("CHF", 999.5):       "CHF 999.50",
+		money("", 0):              "0.00",
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Fatalf("money = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package share hands out short codes that reopen a configuration. Small
+// selections travel inside the code; larger ones are stored and the code
+// carries a reference. Resolving a code always re-validates and re-prices,
+// so a customer never sees a stale price or a configuration rules have since
+// blocked without being told.
+package share
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/sharecode"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/evaluate"
+)
+
+var (
+	// ErrNotFound is returned for stored codes that are unknown or expired.
+	ErrNotFound = errors.New("share code not found")
+	// ErrInvalid is returned for codes that fail to decode and selections
+	// that cannot be shared.
+	ErrInvalid = errors.New("invalid share")
+)
+
+const keyPrefix = "pricing:share:"
+
+// Config tunes code generation. Selections whose inline code is longer than
+// MaxInline are stored for TTL. BaseURL, when set, is prefixed to codes to
+// build share links.
+type Config struct {
+	TTL       time.Duration
+	MaxInline int
+	BaseURL   string
+}
+
+// Share is a freshly issued code.
+type Share struct {
+	Code        string `json:"code"`
+	URL         string `json:"url,omitempty"`
+	Inline      bool   `json:"inline"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Resolved is a decoded code with its current verdict and price.
+type Resolved struct {
+	Code       string                 `json:"code"`
+	Selection  configurator.Selection `json:"selection"`
+	Evaluation evaluate.Evaluation    `json:"evaluation"`
+}
+
+// Service issues and resolves share codes.
+type Service struct {
+	evaluator *evaluate.Evaluator
+	store     cache.Cache
+	cfg       Config
+}
+
+// NewService wires the evaluator used on resolve and the store for large
+// selections.
+func NewService(evaluator *evaluate.Evaluator, store cache.Cache, cfg Config) *Service {
+	return &Service{evaluator: evaluator, store: store, cfg: cfg}
+}
+
+// Create issues a code for the selection. Request context (configuration
+// ID, locale, channel, acknowledgements) is not shared. Equal configurations
+// get equal codes.
+func (s *Service) Create(ctx context.Context, sel configurator.Selection) (Share, error) {
+	if sel.Currency == "" {
+		sel.Currency = "USD"
+	}
+	if err := sel.Validate(configurator.FieldLayout, configurator.FieldCurrency); err != nil {
+		return Share{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	sel.ConfigurationID, sel.Locale, sel.Channel, sel.Acknowledgements = "", "", "", nil
+	sel.Options = sel.SortedOptions()
+
+	out := Share{Fingerprint: sel.Fingerprint()}
+	code, err := sharecode.EncodeInline(sel)
+	if err != nil {
+		return Share{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(code) <= s.cfg.MaxInline {
+		out.Code, out.Inline = code, true
+	} else {
+		sum := sha256.Sum256([]byte(out.Fingerprint))
+		ref := sum[:sharecode.RefSize]
+		payload, err := json.Marshal(sel)
+		if err != nil {
+			return Share{}, err
+		}
+		if err := s.store.Set(ctx, keyPrefix+hex.EncodeToString(ref), string(payload), s.cfg.TTL); err != nil {
+			return Share{}, fmt.Errorf("store share: %w", err)
+		}
+		if out.Code, err = sharecode.EncodeRef(ref); err != nil {
+			return Share{}, err
+		}
+	}
+	if s.cfg.BaseURL != "" {
+		out.URL = s.cfg.BaseURL + out.Code
+	}
+	return out, nil
+}
+
+// Resolve decodes a code and evaluates the selection against current rules
+// and prices for the given locale. Evaluation errors are returned as the
+// evaluator reports them.
+func (s *Service) Resolve(ctx context.Context, code, locale string) (Resolved, error) {
+	payload, err := sharecode.Decode(code)
+	if err != nil {
+		return Resolved{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var sel configurator.Selection
+	if payload.Selection != nil {
+		sel = *payload.Selection
+	} else if sel, err = s.load(ctx, payload.Ref); err != nil {
+		return Resolved{}, err
+	}
+
+	probe := sel
+	probe.Locale = locale
+	eval, err := s.evaluator.Evaluate(ctx, probe)
+	if err != nil {
+		return Resolved{}, err
+	}
+	return Resolved{Code: code, Selection: sel, Evaluation: eval}, nil
+}
+
+func (s *Service) load(ctx context.Context, ref []byte) (configurator.Selection, error) {
+	raw, err := s.store.Get(ctx, keyPrefix+hex.EncodeToString(ref))
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return configurator.Selection{}, ErrNotFound
+	}
+	if err != nil {
+		return configurator.Selection{}, err
+	}
+	var sel configurator.Selection
+	if err := json.Unmarshal([]byte(raw), &sel); err != nil {
+		return configurator.Selection{}, fmt.Errorf("decode share: %w", err)
+	}
+	return sel, nil
+}
@@ -0,0 +1,102 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rulesclient"
+
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/evaluate"
+	"github.com/parvizcorp/kitchen-configurator/services/pricing-go/internal/pricing"
+)
+
+type fakeRules struct {
+	locale *string
+}
+
+func (f fakeRules) ValidateWithFixes(_ context.Context, sel configurator.Selection) (rulesclient.Result, error) {
+	*f.locale = sel.Locale
+	return rulesclient.Result{}, nil
+}
+
+func newService(maxInline int) (*Service, *string) {
+	locale := new(string)
+	prices := pricing.NewService(pricing.NewMatrix(map[string]float64{"galley": 5000}, map[string]float64{"island-counter": 1200}), nil, 0)
+	cfg := Config{TTL: time.Hour, MaxInline: maxInline, BaseURL: "https://kitchens.example/s/"}
+	return NewService(evaluate.NewEvaluator(fakeRules{locale: locale}, prices), cache.NewMemoryCache(), cfg), locale
+}
+
+var sel = configurator.Selection{
+	ConfigurationID: "cfg-1",
+	Module:          "galley",
+	Layout:          "island",
+	Finish:          "matte",
+	Channel:         "sales",
+	Options:         []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}},
+}
+
+func TestSharesResolveToRepricedSelection(t *testing.T) {
+	for _, maxInline := range []int{64, 0} {
+		svc, locale := newService(maxInline)
+		s, err := svc.Create(context.Background(), sel)
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if s.Inline != (maxInline > 0) || s.URL != "https://kitchens.example/s/"+s.Code {
+			t.Fatalf("unexpected share %+v", s)
+		}
+
+		got, err := svc.Resolve(context.Background(), s.Code, "de")
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got.Selection.ConfigurationID != "" || got.Selection.Channel != "" || got.Selection.Fingerprint() != s.Fingerprint {
+			t.Fatalf("expected the shared configuration without request context, got %+v", got.Selection)
+		}
+		if got.Evaluation.Status != evaluate.StatusPriced || got.Evaluation.Estimate.Total == 0 || *locale != "de" {
+			t.Fatalf("expected a fresh evaluation for the resolving locale, got %+v", got.Evaluation)
+		}
+	}
+}
+
+func TestResolveReportsBadAndExpiredCodes(t *testing.T) {
+	svc, _ := newService(0)
+	if _, err := svc.Resolve(context.Background(), "not-a-code", ""); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected an invalid code, got %v", err)
+	}
+
+	s, err := svc.Create(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	other, _ := newService(0)
+	if _, err := other.Resolve(context.Background(), s.Code, ""); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a stored code to be unknown elsewhere, got %v", err)
+	}
+}
+
+func TestReorderedOptionsGetTheSameCode(t *testing.T) {
+	for _, maxInline := range []int{128, 0} {
+		svc, _ := newService(maxInline)
+		a := sel
+		a.Options = []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}, {ID: "drawer-lighting", Quantity: 2}}
+		b := a
+		b.Options = []configurator.SelectionOption{a.Options[1], a.Options[0]}
+
+		first, err := svc.Create(context.Background(), a)
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		second, err := svc.Create(context.Background(), b)
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if first.Inline != (maxInline > 0) || first.Code != second.Code {
+			t.Fatalf("expected one code for both option orders, got %q (inline %v) and %q", first.Code, first.Inline, second.Code)
+		}
+	}
+}
@@ -2,32 +2,58 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"os/signal"
 	"syscall"
 	"time"
 
+	rulesv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/rules/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
 	gologger "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/logger"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rpc"
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/telemetry"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
 	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/config"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/grpcapi"
 	transport "github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/http"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/messages"
 	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/session"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/simulate"
 	"github.com/rs/zerolog"
 )
 
 // App hosts the HTTP server + rule engine.
 type App struct {
 	server            *http.Server
+	grpcServer        *rpc.Server
+	grpcAddr          string
 	shutdownTimeout   time.Duration
 	log               zerolog.Logger
 	telemetryShutdown func(context.Context)
+	rules             *admin.Manager
+	recorder          *simulate.Recorder
+	syncInterval      time.Duration
 }
 
-func New() *App {
+func New() (*App, error) {
 	cfg := config.Load()
 	log := gologger.New("rules-go")
 
+	channelKeys, err := auth.ParseKeys(cfg.ChannelKeys)
+	if err != nil {
+		return nil, fmt.Errorf("RULES_CHANNEL_KEYS: %w", err)
+	}
+	adminKeys, err := auth.ParseKeys(cfg.AdminKeys)
+	if err != nil {
+		return nil, fmt.Errorf("RULES_ADMIN_KEYS: %w", err)
+	}
+	adminKeys = adminKeys.With("admin", cfg.AdminToken)
+
 	telemetryShutdown := telemetry.Init(context.Background(), telemetry.Config{
 		Endpoint:    cfg.OTLPEndpoint,
 		ServiceName: cfg.ServiceName,
@@ -51,8 +77,43 @@ func New() *App {
 		cacheLayer = cache.NewMemoryCache()
 	}
 
-	engine := rules.NewEngine(cacheLayer, cfg.CacheTTL)
-	handler := transport.NewHTTPHandler(log, engine)
+	ruleSet := rules.DefaultRuleSet()
+	if cfg.RulesFile != "" {
+		// A rejected file fails startup rather than serving rules the
+		// operator did not configure.
+		if ruleSet, err = loadRules(log, cfg.RulesFile); err != nil {
+			return nil, fmt.Errorf("RULES_FILE %s: %w", cfg.RulesFile, err)
+		}
+	}
+	if cfg.ErgonomicsPack {
+		ruleSet = ruleSet.WithPack(rules.ErgonomicsPack())
+	}
+	if cfg.AckSecret == "" && ruleSet.IssuesAckTokens() {
+		return nil, errors.New("RULES_ACK_SECRET is required when a channel policy marks errors overridable")
+	}
+	opts := admin.Options{Cache: cacheLayer, TTL: cfg.CacheTTL, AckSecret: []byte(cfg.AckSecret)}
+	manager, err := admin.NewManager(log, cacheLayer, ruleSet, opts)
+	if err != nil {
+		return nil, fmt.Errorf("rules admin: %w", err)
+	}
+	if cfg.RedisAddr == "" {
+		log.Warn().Msg("REDIS_ADDR unset, published rule sets are not shared between replicas")
+	}
+	if adminKeys.Len() == 0 {
+		log.Info().Msg("RULES_ADMIN_KEYS and RULES_ADMIN_TOKEN unset, rule admin API disabled")
+	}
+	if channelKeys.Len() == 0 {
+		log.Info().Str("channel", cfg.DefaultChannel).Msg("RULES_CHANNEL_KEYS unset, every caller gets the default channel policy")
+	}
+	recorder := simulate.NewRecorder(log, cacheLayer, cfg.SampleRate, cfg.SampleSize)
+	catalog := messages.Default()
+	handler := transport.NewHTTPHandler(log, manager, catalog, transport.Options{
+		AdminKeys:      adminKeys,
+		Recorder:       recorder,
+		Sessions:       session.NewStore(cacheLayer, cfg.SessionTTL),
+		ChannelKeys:    channelKeys,
+		DefaultChannel: cfg.DefaultChannel,
+	})
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
@@ -62,12 +123,46 @@ func New() *App {
 		IdleTimeout:  30 * time.Second,
 	}
 
+	grpcServer := rpc.NewServer()
+	rulesv1.RegisterRulesServiceServer(grpcServer, grpcapi.NewServer(log, manager, catalog, grpcapi.Options{
+		Recorder:       recorder,
+		ChannelKeys:    channelKeys,
+		AdminKeys:      adminKeys,
+		DefaultChannel: cfg.DefaultChannel,
+	}))
+
 	return &App{
 		server:            srv,
+		grpcServer:        grpcServer,
+		grpcAddr:          ":" + cfg.GRPCPort,
 		shutdownTimeout:   cfg.ShutdownTimeout,
 		log:               log,
 		telemetryShutdown: telemetryShutdown,
+		rules:             manager,
+		recorder:          recorder,
+		syncInterval:      cfg.SyncInterval,
+	}, nil
+}
+
+// loadRules reads a rule file, logs the static analysis report and refuses
+// sets that would make part of the catalogue unconfigurable.
+func loadRules(log zerolog.Logger, path string) (rules.RuleSet, error) {
+	set, err := rules.LoadRuleSet(path)
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+	report := rules.Analyze(set, rules.DefaultDomain())
+	for _, f := range report.Findings {
+		log.Warn().
+			Str("kind", f.Kind).
+			Str("severity", f.Severity).
+			Strs("rules", f.Rules).
+			Msg(f.Message)
 	}
+	if report.HasErrors() {
+		return rules.RuleSet{}, fmt.Errorf("rule set %s has blocking analysis findings", set.Version)
+	}
+	return set, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
@@ -81,19 +176,41 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
-	errCh := make(chan error, 1)
+	go a.rules.Run(ctx, a.syncInterval)
+	go a.recorder.Run(ctx, a.syncInterval)
+
+	lis, err := net.Listen("tcp", a.grpcAddr)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 2)
 	go func() {
 		a.log.Info().Str("addr", a.server.Addr).Msg("rules service listening")
 		errCh <- a.server.ListenAndServe()
 	}()
+	go func() {
+		a.log.Info().Str("addr", a.grpcAddr).Msg("rules gRPC service listening")
+		errCh <- a.grpcServer.Serve(lis)
+	}()
 
+	var runErr error
 	select {
 	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
-		defer cancel()
 		a.log.Info().Msg("shutting down rules service")
-		return a.server.Shutdown(shutdownCtx)
-	case err := <-errCh:
-		return err
+	case runErr = <-errCh:
+	}
+
+	// Both servers share one lifecycle: whichever stops first takes the
+	// other down with it.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+	a.grpcServer.Shutdown(shutdownCtx)
+	if err := a.server.Shutdown(shutdownCtx); err != nil && runErr == nil {
+		runErr = err
+	}
+	if errors.Is(runErr, http.ErrServerClosed) {
+		return nil
 	}
+	return runErr
 }
@@ -0,0 +1,46 @@
+// Command rulecheck statically analyzes a rule set and exits non-zero when it
+// contains findings that would make part of the catalogue unconfigurable.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func main() {
+	path := flag.String("rules", "", "path to a JSON rule set (defaults to the built-in rules)")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	set := rules.DefaultRuleSet()
+	if *path != "" {
+		loaded, err := rules.LoadRuleSet(*path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		set = loaded
+	}
+
+	report := rules.Analyze(set, rules.DefaultDomain())
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		fmt.Printf("rule set %s: %d finding(s)\n", report.Version, len(report.Findings))
+		for _, f := range report.Findings {
+			fmt.Printf("%-7s %-16s %v %s\n", f.Severity, f.Kind, f.Rules, f.Message)
+		}
+	}
+
+	if report.HasErrors() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,70 @@
+// Command rulesim replays a recorded selection corpus against the current and
+// a candidate rule set and reports which outcomes would change.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/simulate"
+)
+
+func main() {
+	corpusPath := flag.String("corpus", "", "path to a JSON array of selections, as served by GET /v1/rules/admin/corpus")
+	currentPath := flag.String("current", "", "path to the current rule set (defaults to the built-in rules)")
+	candidatePath := flag.String("candidate", "", "path to the candidate rule set")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	if *corpusPath == "" || *candidatePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	current := rules.DefaultRuleSet()
+	if *currentPath != "" {
+		loaded, err := rules.LoadRuleSet(*currentPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		current = loaded
+	}
+	candidate, err := rules.LoadRuleSet(*candidatePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	raw, err := os.ReadFile(*corpusPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var corpus []rules.Selection
+	if err := json.Unmarshal(raw, &corpus); err != nil {
+		log.Fatalf("decode corpus: %v", err)
+	}
+
+	report, err := simulate.Replay(context.Background(), corpus, current, candidate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	fmt.Printf("%s -> %s: %d selection(s), %d changed, %d skipped\n",
+		report.From, report.To, report.Selections, report.Changed, report.Skipped)
+	fmt.Printf("newly blocked %d %v\n", report.NewlyBlocked.Count, report.NewlyBlocked.Examples)
+	fmt.Printf("unblocked     %d %v\n", report.Unblocked.Count, report.Unblocked.Examples)
+	for _, c := range report.Codes {
+		fmt.Printf("%-32s +%d -%d ~%d %v\n", c.Code, c.Added, c.Removed, c.SeverityChanged, c.Examples)
+	}
+}
@@ -8,7 +8,11 @@ import (
 )
 
 func main() {
-	if err := app.New().Run(context.Background()); err != nil {
+	a, err := app.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := a.Run(context.Background()); err != nil {
 		log.Fatal(err)
 	}
 }
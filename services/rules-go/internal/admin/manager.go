@@ -0,0 +1,492 @@
+// Package admin manages rule set drafts, publication and rollback for a
+// running fleet. State lives in the shared cache so every replica converges
+// on the same active version. The version history and audit trail are
+// append-only lists, and every transition is committed together with its
+// audit record in one atomic batch.
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+var (
+	// ErrNotFound is returned for unknown drafts and versions.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid wraps validation failures of a draft.
+	ErrInvalid = errors.New("invalid rule set")
+	// ErrConflict is returned when a version is already published or active.
+	ErrConflict = errors.New("conflict")
+	// ErrAckSecret is returned for rule sets whose policies issue
+	// acknowledgement tokens when no shared secret is configured.
+	ErrAckSecret = errors.New("overridable policies need an acknowledgement secret")
+)
+
+const (
+	keyPrefix       = "rules:admin:"
+	keyActive       = keyPrefix + "active"
+	keyVersions     = keyPrefix + "version-log"
+	keyAudit        = keyPrefix + "audit-log"
+	keyBootstrapped = keyPrefix + "bootstrapped"
+
+	draftTTL = 7 * 24 * time.Hour
+)
+
+// Draft is an uploaded rule set awaiting publication.
+type Draft struct {
+	ID        string        `json:"id"`
+	Actor     string        `json:"actor"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Set       rules.RuleSet `json:"set"`
+}
+
+// VersionRecord is one entry in the publication history.
+type VersionRecord struct {
+	Version     string    `json:"version"`
+	Actor       string    `json:"actor"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Draft       string    `json:"draft,omitempty"`
+	Active      bool      `json:"active"`
+}
+
+// AuditRecord captures one state transition.
+type AuditRecord struct {
+	ID          string    `json:"id"`
+	At          time.Time `json:"at"`
+	Actor       string    `json:"actor"`
+	Action      string    `json:"action"`
+	Draft       string    `json:"draft,omitempty"`
+	FromVersion string    `json:"fromVersion,omitempty"`
+	ToVersion   string    `json:"toVersion,omitempty"`
+}
+
+// Audit actions.
+const (
+	ActionDraftCreated = "draft.created"
+	ActionPublished    = "published"
+	ActionRolledBack   = "rolled-back"
+	ActionBootstrapped = "bootstrapped"
+	ActionSynced       = "synced"
+)
+
+// Check is the outcome of validating a draft.
+type Check struct {
+	Valid  bool          `json:"valid"`
+	Error  string        `json:"error,omitempty"`
+	Report *rules.Report `json:"report,omitempty"`
+}
+
+// Options configures how the manager builds engines.
+type Options struct {
+	Cache     cache.Cache
+	TTL       time.Duration
+	AckSecret []byte
+	Domain    rules.Domain
+}
+
+// Manager owns the active engine and swaps it atomically on publish,
+// rollback or when another replica changed the active version.
+type Manager struct {
+	log   zerolog.Logger
+	store cache.Cache
+	opts  Options
+
+	mu     sync.Mutex
+	set    rules.RuleSet
+	active atomic.Pointer[rules.Engine]
+}
+
+// NewManager starts from the rule set the process booted with. Call Sync to
+// adopt a version published earlier by any replica. Sets whose policies issue
+// acknowledgement tokens need opts.AckSecret, so that a token signed by one
+// replica verifies on every other and after a restart.
+func NewManager(log zerolog.Logger, store cache.Cache, set rules.RuleSet, opts Options) (*Manager, error) {
+	if len(opts.Domain.Layouts) == 0 {
+		opts.Domain = rules.DefaultDomain()
+	}
+	m := &Manager{log: log, store: store, opts: opts}
+	engine, err := m.build(set)
+	if err != nil {
+		return nil, err
+	}
+	m.set = set
+	m.active.Store(engine)
+	return m, nil
+}
+
+// Engine returns the engine for the active version.
+func (m *Manager) Engine() *rules.Engine {
+	return m.active.Load()
+}
+
+// Active returns the active rule set.
+func (m *Manager) Active() rules.RuleSet {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.set
+}
+
+// DecodeRuleSet reads a rule set without validating it, so drafts can be
+// stored and inspected before they are fixed.
+func DecodeRuleSet(raw []byte) (rules.RuleSet, error) {
+	var set rules.RuleSet
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&set); err != nil {
+		return rules.RuleSet{}, fmt.Errorf("decode rule set: %w", err)
+	}
+	return set, nil
+}
+
+// CreateDraft stores a rule set for later validation and publication.
+func (m *Manager) CreateDraft(ctx context.Context, set rules.RuleSet, actor string) (Draft, error) {
+	draft := Draft{ID: uuid.NewString(), Actor: actor, CreatedAt: time.Now().UTC(), Set: set}
+	var b batch
+	b.add(cache.Write{Key: draftKey(draft.ID), TTL: draftTTL}, draft)
+	record := AuditRecord{Actor: actor, Action: ActionDraftCreated, Draft: draft.ID, ToVersion: set.Version}
+	if err := m.commit(ctx, record, b); err != nil {
+		return Draft{}, err
+	}
+	return draft, nil
+}
+
+// Draft loads a stored draft.
+func (m *Manager) Draft(ctx context.Context, id string) (Draft, error) {
+	var draft Draft
+	if err := m.get(ctx, draftKey(id), &draft); err != nil {
+		return Draft{}, err
+	}
+	return draft, nil
+}
+
+// Check validates a draft structurally and runs the static analyzer on it.
+func (m *Manager) Check(ctx context.Context, id string) (Check, error) {
+	draft, err := m.Draft(ctx, id)
+	if err != nil {
+		return Check{}, err
+	}
+	return m.check(ctx, draft.Set, m.Active().Version), nil
+}
+
+func (m *Manager) check(ctx context.Context, set rules.RuleSet, active string) Check {
+	if set.Version == "" {
+		return Check{Error: "version is required"}
+	}
+	if err := set.Validate(); err != nil {
+		return Check{Error: err.Error()}
+	}
+	if err := m.checkAckSecret(set); err != nil {
+		return Check{Error: err.Error()}
+	}
+	report := rules.Analyze(set, m.opts.Domain)
+	if report.HasErrors() {
+		return Check{Error: "analysis reported blocking findings", Report: &report}
+	}
+	if set.Version == active || m.published(ctx, set.Version) {
+		return Check{Error: fmt.Sprintf("version %s is already published", set.Version), Report: &report}
+	}
+	return Check{Valid: true, Report: &report}
+}
+
+// Diff compares a draft with the active rule set.
+func (m *Manager) Diff(ctx context.Context, id string) (rules.RuleSetDiff, error) {
+	draft, err := m.Draft(ctx, id)
+	if err != nil {
+		return rules.RuleSetDiff{}, err
+	}
+	return rules.DiffRuleSets(m.Active(), draft.Set), nil
+}
+
+// Publish makes a valid draft the active version on every replica.
+func (m *Manager) Publish(ctx context.Context, id, actor string) (VersionRecord, error) {
+	draft, err := m.Draft(ctx, id)
+	if err != nil {
+		return VersionRecord{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	check := m.check(ctx, draft.Set, m.set.Version)
+	if !check.Valid {
+		if draft.Set.Version == m.set.Version || m.published(ctx, draft.Set.Version) {
+			return VersionRecord{}, fmt.Errorf("%w: %s", ErrConflict, check.Error)
+		}
+		return VersionRecord{}, fmt.Errorf("%w: %s", ErrInvalid, check.Error)
+	}
+	engine, err := m.build(draft.Set)
+	if err != nil {
+		return VersionRecord{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := m.bootstrap(ctx); err != nil {
+		return VersionRecord{}, err
+	}
+	record := VersionRecord{Version: draft.Set.Version, Actor: actor, PublishedAt: time.Now().UTC(), Draft: draft.ID}
+	// Claiming the set key guards against two replicas publishing the same
+	// version at once; the loser's batch is discarded whole.
+	var b batch
+	b.add(cache.Write{Key: setKey(record.Version), IfAbsent: true}, draft.Set)
+	b.add(cache.Write{Key: keyVersions, Append: true}, record)
+	b.add(cache.Write{Key: keyActive}, record.Version)
+	audit := AuditRecord{Actor: actor, Action: ActionPublished, Draft: draft.ID, FromVersion: m.set.Version, ToVersion: record.Version}
+	if err := m.commit(ctx, audit, b); err != nil {
+		if errors.Is(err, cache.ErrConflict) {
+			return VersionRecord{}, fmt.Errorf("%w: version %s is already published", ErrConflict, record.Version)
+		}
+		return VersionRecord{}, err
+	}
+	m.swap(draft.Set, engine)
+	record.Active = true
+	return record, nil
+}
+
+// Rollback re-activates an earlier published version.
+func (m *Manager) Rollback(ctx context.Context, version, actor string) (VersionRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if version == m.set.Version {
+		return VersionRecord{}, fmt.Errorf("%w: version %s is already active", ErrConflict, version)
+	}
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return VersionRecord{}, err
+	}
+	var record *VersionRecord
+	for i := range versions {
+		if versions[i].Version == version {
+			record = &versions[i]
+		}
+	}
+	if record == nil {
+		return VersionRecord{}, fmt.Errorf("version %s: %w", version, ErrNotFound)
+	}
+	var set rules.RuleSet
+	if err := m.get(ctx, setKey(version), &set); err != nil {
+		return VersionRecord{}, err
+	}
+	engine, err := m.build(set)
+	if err != nil {
+		return VersionRecord{}, err
+	}
+	var b batch
+	b.add(cache.Write{Key: keyActive}, version)
+	audit := AuditRecord{Actor: actor, Action: ActionRolledBack, FromVersion: m.set.Version, ToVersion: version}
+	if err := m.commit(ctx, audit, b); err != nil {
+		return VersionRecord{}, err
+	}
+	m.swap(set, engine)
+	out := *record
+	out.Active = true
+	return out, nil
+}
+
+// Versions lists the publication history, oldest first.
+func (m *Manager) Versions(ctx context.Context) ([]VersionRecord, error) {
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := m.Engine().Version()
+	for i := range versions {
+		versions[i].Active = versions[i].Version == active
+	}
+	return versions, nil
+}
+
+// Audit returns the complete audit trail, oldest first.
+func (m *Manager) Audit(ctx context.Context) ([]AuditRecord, error) {
+	return list[AuditRecord](ctx, m.store, keyAudit)
+}
+
+// Sync adopts the version another replica published. It is a no-op when the
+// store has no active version or it is already loaded.
+func (m *Manager) Sync(ctx context.Context) error {
+	var version string
+	if err := m.get(ctx, keyActive, &version); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if version == m.set.Version {
+		return nil
+	}
+	var set rules.RuleSet
+	if err := m.get(ctx, setKey(version), &set); err != nil {
+		return err
+	}
+	engine, err := m.build(set)
+	if err != nil {
+		return err
+	}
+	from := m.set.Version
+	m.swap(set, engine)
+	m.log.Info().Str("action", ActionSynced).Str("from", from).Str("to", version).Msg("rule set synced")
+	return nil
+}
+
+// Run polls the store until ctx is cancelled so this replica follows
+// publications made elsewhere.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	if err := m.Sync(ctx); err != nil {
+		m.log.Warn().Err(err).Msg("rule set sync failed")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Sync(ctx); err != nil {
+				m.log.Warn().Err(err).Msg("rule set sync failed")
+			}
+		}
+	}
+}
+
+// bootstrap records the boot rule set as the first version so operators can
+// roll back to it after the first publish. The marker key makes it happen
+// once per store even when several replicas publish concurrently. Callers
+// hold m.mu.
+func (m *Manager) bootstrap(ctx context.Context) error {
+	if _, err := m.store.Get(ctx, keyBootstrapped); err == nil {
+		return nil
+	}
+	record := VersionRecord{Version: m.set.Version, Actor: "system", PublishedAt: time.Now().UTC()}
+	var b batch
+	b.add(cache.Write{Key: keyBootstrapped, IfAbsent: true}, record.Version)
+	b.add(cache.Write{Key: setKey(record.Version)}, m.set)
+	b.add(cache.Write{Key: keyVersions, Append: true}, record)
+	err := m.commit(ctx, AuditRecord{Actor: "system", Action: ActionBootstrapped, ToVersion: record.Version}, b)
+	if errors.Is(err, cache.ErrConflict) {
+		return nil
+	}
+	return err
+}
+
+// swap makes set the local active version. Callers hold m.mu.
+func (m *Manager) swap(set rules.RuleSet, engine *rules.Engine) {
+	m.set = set
+	m.active.Store(engine)
+}
+
+func (m *Manager) build(set rules.RuleSet) (*rules.Engine, error) {
+	if err := m.checkAckSecret(set); err != nil {
+		return nil, err
+	}
+	engine, err := rules.NewEngineWithRules(set, m.opts.Cache, m.opts.TTL)
+	if err != nil {
+		return nil, err
+	}
+	engine.SetAckSecret(m.opts.AckSecret)
+	return engine, nil
+}
+
+// checkAckSecret refuses sets that would sign acknowledgement tokens with a
+// per-process key.
+func (m *Manager) checkAckSecret(set rules.RuleSet) error {
+	if len(m.opts.AckSecret) == 0 && set.IssuesAckTokens() {
+		return ErrAckSecret
+	}
+	return nil
+}
+
+// published reports whether a version's rule set was ever stored.
+func (m *Manager) published(ctx context.Context, version string) bool {
+	_, err := m.store.Get(ctx, setKey(version))
+	return err == nil
+}
+
+func (m *Manager) versions(ctx context.Context) ([]VersionRecord, error) {
+	return list[VersionRecord](ctx, m.store, keyVersions)
+}
+
+// commit stores the writes and the audit record in one atomic batch, so a
+// transition is never stored without its audit entry or the reverse. The
+// record is logged once it is stored.
+func (m *Manager) commit(ctx context.Context, record AuditRecord, b batch) error {
+	record.ID = uuid.NewString()
+	record.At = time.Now().UTC()
+	b.add(cache.Write{Key: keyAudit, Append: true}, record)
+	if b.err != nil {
+		return b.err
+	}
+	if err := m.store.Commit(ctx, b.writes...); err != nil {
+		return err
+	}
+	m.log.Info().
+		Str("audit", record.ID).
+		Str("actor", record.Actor).
+		Str("action", record.Action).
+		Str("draft", record.Draft).
+		Str("from", record.FromVersion).
+		Str("to", record.ToVersion).
+		Msg("rules admin transition")
+	return nil
+}
+
+func (m *Manager) get(ctx context.Context, key string, out any) error {
+	raw, err := m.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return fmt.Errorf("%s: %w", key, ErrNotFound)
+		}
+		return err
+	}
+	return json.Unmarshal([]byte(raw), out)
+}
+
+// batch collects JSON-encoded writes for one atomic commit and keeps the
+// first encoding error.
+type batch struct {
+	writes []cache.Write
+	err    error
+}
+
+func (b *batch) add(w cache.Write, value any) {
+	if b.err != nil {
+		return
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		b.err = err
+		return
+	}
+	w.Value = string(payload)
+	b.writes = append(b.writes, w)
+}
+
+func list[T any](ctx context.Context, store cache.Cache, key string) ([]T, error) {
+	raw, err := store.List(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, 0, len(raw))
+	for _, item := range raw {
+		var v T
+		if err := json.Unmarshal([]byte(item), &v); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func draftKey(id string) string { return keyPrefix + "draft:" + id }
+
+func setKey(version string) string { return keyPrefix + "set:" + version }
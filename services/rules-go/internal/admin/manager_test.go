@@ -0,0 +1,208 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func newManager(t *testing.T, store cache.Cache) *Manager {
+	t.Helper()
+	m, err := NewManager(zerolog.Nop(), store, rules.DefaultRuleSet(), Options{AckSecret: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return m
+}
+
+func candidate(version string) rules.RuleSet {
+	set := rules.DefaultRuleSet()
+	set.Version = version
+	set.Rules = append([]rules.Rule(nil), set.Rules...)
+	set.Rules[3].MaxMM = 8000
+	return set
+}
+
+func TestPublishConvergesReplicasAndRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	primary := newManager(t, store)
+	replica := newManager(t, store)
+
+	draft, err := primary.CreateDraft(ctx, candidate("2024-06"), "ops@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diff, err := primary.Diff(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Rules) != 1 || diff.Rules[0].ID != "u-shape-length" || diff.Rules[0].Change != rules.ChangeChanged {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+
+	if _, err := primary.Publish(ctx, draft.ID, "ops@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if primary.Engine().Version() != "2024-06" {
+		t.Fatalf("publish should swap the local engine")
+	}
+	if err := replica.Sync(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replica.Engine().Version() != "2024-06" {
+		t.Fatalf("replica should converge on the published version, got %s", replica.Engine().Version())
+	}
+
+	if _, err := replica.Rollback(ctx, "builtin", "oncall"); err != nil {
+		t.Fatalf("rollback to the boot set should work: %v", err)
+	}
+	if err := primary.Sync(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if primary.Engine().Version() != "builtin" {
+		t.Fatalf("primary should follow the rollback, got %s", primary.Engine().Version())
+	}
+
+	records, err := primary.Audit(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := make([]string, 0, len(records))
+	for _, r := range records {
+		actions = append(actions, r.Action)
+	}
+	want := []string{ActionDraftCreated, ActionBootstrapped, ActionPublished, ActionRolledBack}
+	if len(actions) != len(want) {
+		t.Fatalf("expected audit %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("expected audit %v, got %v", want, actions)
+		}
+	}
+}
+
+func TestPublishRejectsInvalidAndDuplicateDrafts(t *testing.T) {
+	ctx := context.Background()
+	m := newManager(t, cache.NewMemoryCache())
+
+	broken := candidate("broken")
+	broken.Rules = append(broken.Rules, rules.Rule{ID: "no-kind"})
+	draft, _ := m.CreateDraft(ctx, broken, "ops")
+	check, err := m.Check(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if check.Valid || check.Error == "" {
+		t.Fatalf("expected the draft to fail validation, got %+v", check)
+	}
+	if _, err := m.Publish(ctx, draft.ID, "ops"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+
+	good, _ := m.CreateDraft(ctx, candidate("v2"), "ops")
+	if _, err := m.Publish(ctx, good.ID, "ops"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, _ := m.CreateDraft(ctx, candidate("v2"), "ops")
+	if _, err := m.Publish(ctx, again.ID, "ops"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict for a reused version, got %v", err)
+	}
+	if _, err := m.Rollback(ctx, "missing", "ops"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// failingCommits stores nothing through Commit, like a Redis outage during a
+// transition.
+type failingCommits struct{ cache.Cache }
+
+func (failingCommits) Commit(context.Context, ...cache.Write) error {
+	return errors.New("redis unavailable")
+}
+
+func TestTransitionsFailWhenTheAuditRecordCannotBeStored(t *testing.T) {
+	ctx := context.Background()
+	m := newManager(t, failingCommits{cache.NewMemoryCache()})
+	if _, err := m.CreateDraft(ctx, candidate("v2"), "ops"); err == nil {
+		t.Fatalf("expected the draft to fail with its audit record")
+	}
+}
+
+func TestConcurrentPublishesOfOneVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	replicas := []*Manager{newManager(t, store), newManager(t, store), newManager(t, store)}
+
+	errs := make(chan error, len(replicas))
+	var wg sync.WaitGroup
+	for _, m := range replicas {
+		draft, err := m.CreateDraft(ctx, candidate("v2"), "ops")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wg.Add(1)
+		go func(m *Manager, id string) {
+			defer wg.Done()
+			_, err := m.Publish(ctx, id, "ops")
+			errs <- err
+		}(m, draft.ID)
+	}
+	wg.Wait()
+	close(errs)
+
+	published, conflicts := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			published++
+		case errors.Is(err, ErrConflict):
+			conflicts++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if published != 1 || conflicts != len(replicas)-1 {
+		t.Fatalf("expected one publish and %d conflicts, got %d and %d", len(replicas)-1, published, conflicts)
+	}
+
+	versions, err := replicas[0].Versions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != "builtin" || versions[1].Version != "v2" {
+		t.Fatalf("expected one bootstrap and one v2 record, got %+v", versions)
+	}
+}
+
+func TestOverridablePoliciesRequireAnAckSecret(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	if _, err := NewManager(zerolog.Nop(), store, rules.DefaultRuleSet(), Options{}); !errors.Is(err, ErrAckSecret) {
+		t.Fatalf("expected ErrAckSecret without a secret, got %v", err)
+	}
+
+	plain := rules.DefaultRuleSet()
+	plain.Policies = nil
+	m, err := NewManager(zerolog.Nop(), store, plain, Options{})
+	if err != nil {
+		t.Fatalf("expected a set without overridable policies to start, got %v", err)
+	}
+	draft, err := m.CreateDraft(ctx, candidate("2024-06"), "ops")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if check, err := m.Check(ctx, draft.ID); err != nil || check.Valid {
+		t.Fatalf("expected the draft check to fail, got %+v, %v", check, err)
+	}
+	if _, err := m.Publish(ctx, draft.ID, "ops"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid publishing overridable policies without a secret, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 type Config struct {
 	HTTPPort          string
+	GRPCPort          string
 	RedisAddr         string
 	RedisPassword     string
 	RedisDB           int
@@ -17,11 +18,23 @@ type Config struct {
 	TelemetryInsecure bool
 	ServiceName       string
 	Environment       string
+	RulesFile         string
+	ErgonomicsPack    bool
+	AckSecret         string
+	AdminToken        string
+	AdminKeys         string
+	ChannelKeys       string
+	DefaultChannel    string
+	SyncInterval      time.Duration
+	SampleRate        float64
+	SampleSize        int
+	SessionTTL        time.Duration
 }
 
 func Load() Config {
 	cfg := Config{
 		HTTPPort:          valueOrDefault("HTTP_PORT", "4110"),
+		GRPCPort:          valueOrDefault("GRPC_PORT", "4111"),
 		RedisAddr:         os.Getenv("REDIS_ADDR"),
 		RedisPassword:     os.Getenv("REDIS_PASSWORD"),
 		CacheTTL:          durationOrDefault("CACHE_TTL", time.Minute*2),
@@ -30,6 +43,17 @@ func Load() Config {
 		TelemetryInsecure: boolOrDefault("OTEL_EXPORTER_OTLP_INSECURE", true),
 		ServiceName:       valueOrDefault("OTEL_SERVICE_NAME", "rules-go"),
 		Environment:       valueOrDefault("ENVIRONMENT", "local"),
+		RulesFile:         os.Getenv("RULES_FILE"),
+		ErgonomicsPack:    boolOrDefault("RULES_ERGONOMICS", false),
+		AckSecret:         os.Getenv("RULES_ACK_SECRET"),
+		AdminToken:        os.Getenv("RULES_ADMIN_TOKEN"),
+		AdminKeys:         os.Getenv("RULES_ADMIN_KEYS"),
+		ChannelKeys:       os.Getenv("RULES_CHANNEL_KEYS"),
+		DefaultChannel:    os.Getenv("RULES_DEFAULT_CHANNEL"),
+		SyncInterval:      durationOrDefault("RULES_SYNC_INTERVAL", time.Second*5),
+		SampleRate:        floatOrDefault("RULES_SAMPLE_RATE", 0.01),
+		SampleSize:        intOrDefault("RULES_SAMPLE_SIZE", 5000),
+		SessionTTL:        durationOrDefault("RULES_SESSION_TTL", time.Minute*30),
 	}
 
 	if v := os.Getenv("REDIS_DB"); v != "" {
@@ -57,6 +81,24 @@ func durationOrDefault(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+func floatOrDefault(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func intOrDefault(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
 func boolOrDefault(key string, fallback bool) bool {
 	if v := os.Getenv(key); v != "" {
 		switch v {
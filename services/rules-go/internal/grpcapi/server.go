@@ -0,0 +1,219 @@
+// Package grpcapi serves rule validation over gRPC. It mirrors the chi
+// validate endpoint: same engine, sampling, repair search and localisation.
+package grpcapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	configuratorv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/configurator/v1"
+	rulesv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/rules/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/messages"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/simulate"
+)
+
+const (
+	// maxBatch bounds one BatchValidate call.
+	maxBatch = 100
+	// batchWorkers bounds how many selections of a batch are validated at once.
+	batchWorkers = 8
+	// validateTimeout matches the HTTP handler's per-validation deadline.
+	validateTimeout = 1500 * time.Millisecond
+)
+
+// Options carries the optional parts of the gRPC surface. The keys and
+// default channel are the HTTP handler's, so both transports resolve a
+// caller to the same channel policy.
+type Options struct {
+	// Recorder samples validated selections for impact simulation.
+	Recorder *simulate.Recorder
+	// ChannelKeys maps API keys, sent as "authorization: Bearer <key>"
+	// metadata, to the channel whose policy applies.
+	ChannelKeys auth.Keys
+	// AdminKeys authenticate internal callers, who get the default channel.
+	AdminKeys auth.Keys
+	// DefaultChannel is the policy for callers without a key.
+	DefaultChannel string
+}
+
+// Server implements rulesv1.RulesServiceServer.
+type Server struct {
+	rulesv1.UnimplementedRulesServiceServer
+
+	log      zerolog.Logger
+	manager  *admin.Manager
+	messages *messages.Catalog
+	opts     Options
+}
+
+// NewServer validates against the manager's live engine.
+func NewServer(log zerolog.Logger, manager *admin.Manager, catalog *messages.Catalog, opts Options) *Server {
+	return &Server{log: log, manager: manager, messages: catalog, opts: opts}
+}
+
+// Validate evaluates one selection.
+func (s *Server) Validate(ctx context.Context, req *rulesv1.ValidateRequest) (*rulesv1.ValidateResponse, error) {
+	channel, err := s.channel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, st := s.validate(ctx, req, channel)
+	if st != nil {
+		return nil, st.Err()
+	}
+	return resp, nil
+}
+
+// BatchValidate evaluates each selection independently; one invalid
+// selection does not fail the batch. Once the call is cancelled no further
+// selections are started and the call fails with the context's status.
+func (s *Server) BatchValidate(ctx context.Context, req *rulesv1.BatchValidateRequest) (*rulesv1.BatchValidateResponse, error) {
+	if n := len(req.GetRequests()); n == 0 || n > maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "requests must list between 1 and %d selections", maxBatch)
+	}
+	channel, err := s.channel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*rulesv1.BatchValidateResult, len(req.GetRequests()))
+	sem := make(chan struct{}, batchWorkers)
+	var wg sync.WaitGroup
+	for i, item := range req.GetRequests() {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return nil, status.FromContextError(err).Err()
+		}
+		wg.Add(1)
+		go func(i int, item *rulesv1.ValidateRequest) {
+			defer func() { <-sem; wg.Done() }()
+			resp, st := s.validate(ctx, item, channel)
+			if st != nil {
+				results[i] = &rulesv1.BatchValidateResult{Outcome: &rulesv1.BatchValidateResult_Error{Error: st.Proto()}}
+				return
+			}
+			results[i] = &rulesv1.BatchValidateResult{Outcome: &rulesv1.BatchValidateResult_Response{Response: resp}}
+		}(i, item)
+	}
+	wg.Wait()
+	return &rulesv1.BatchValidateResponse{Results: results}, nil
+}
+
+// channel resolves the caller's bearer key like the HTTP handler: no key
+// gets the default channel, an admin key keeps it, and an unknown key is
+// rejected rather than downgraded. Selections and other metadata cannot
+// choose the channel.
+func (s *Server) channel(ctx context.Context) (string, error) {
+	token := auth.Bearer(incoming(ctx, "authorization"))
+	if token == "" {
+		return s.opts.DefaultChannel, nil
+	}
+	if _, ok := s.opts.AdminKeys.Lookup(token); ok {
+		return s.opts.DefaultChannel, nil
+	}
+	if channel, ok := s.opts.ChannelKeys.Lookup(token); ok {
+		return channel, nil
+	}
+	return "", status.Error(codes.Unauthenticated, auth.ErrUnauthorized.Error())
+}
+
+func (s *Server) validate(ctx context.Context, req *rulesv1.ValidateRequest, channel string) (*rulesv1.ValidateResponse, *status.Status) {
+	sel := configuratorv1.ToSelection(req.GetSelection())
+	if sel.ConfigurationID == "" {
+		sel.ConfigurationID = uuid.NewString()
+	}
+	sel.Channel = channel
+
+	ctx, cancel := context.WithTimeout(ctx, validateTimeout)
+	defer cancel()
+
+	engine := s.manager.Engine()
+	result, err := engine.Validate(ctx, sel)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("rules validation failed")
+		// A timed out or cancelled item is not the caller's mistake; its
+		// context status lets the caller retry.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr)
+		}
+		return nil, status.New(codes.InvalidArgument, err.Error())
+	}
+	s.opts.Recorder.Record(sel)
+	if result.Blocking && req.GetFixes() {
+		if result.Fixes, err = engine.Repairs(ctx, sel); err != nil {
+			s.log.Warn().Err(err).Msg("rules repair search failed")
+		}
+	}
+
+	// Results are cached locale-free; render text only after the lookup.
+	acceptLanguage := req.GetAcceptLanguage()
+	if acceptLanguage == "" {
+		acceptLanguage = incoming(ctx, "accept-language")
+	}
+	s.messages.Localize(&result, s.messages.Negotiate(sel.Locale, acceptLanguage))
+	return validateResponse(result), nil
+}
+
+// incoming reads the first value of a metadata key.
+func incoming(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func validateResponse(result rules.ValidationResult) *rulesv1.ValidateResponse {
+	out := &rulesv1.ValidateResponse{
+		ConfigurationId: result.ConfigurationID,
+		Fingerprint:     result.Fingerprint,
+		Blocking:        result.Blocking,
+		Packs:           result.Packs,
+		Locale:          result.Locale,
+		Policy:          result.Policy,
+		LatencyMicros:   result.LatencyMicros,
+		Cached:          result.Cached,
+	}
+	for _, v := range result.Violations {
+		out.Violations = append(out.Violations, &rulesv1.Violation{
+			Rule:         v.Rule,
+			Code:         v.Code,
+			Severity:     v.Severity,
+			Message:      v.Message,
+			Paths:        v.Paths,
+			Options:      v.Options,
+			Params:       rulesv1.Params(v.Params),
+			Overridable:  v.Overridable,
+			AckToken:     v.AckToken,
+			Acknowledged: v.Acknowledged,
+		})
+	}
+	for _, f := range result.Fixes {
+		fix := &rulesv1.Fix{}
+		for _, c := range f.Changes {
+			fix.Changes = append(fix.Changes, &rulesv1.Change{
+				Op:       c.Op,
+				Option:   c.Option,
+				Quantity: int32(c.Quantity),
+				Layout:   c.Layout,
+				Finish:   c.Finish,
+			})
+		}
+		out.Fixes = append(out.Fixes, fix)
+	}
+	return out
+}
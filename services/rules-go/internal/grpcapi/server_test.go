@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	configuratorv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/configurator/v1"
+	rulesv1 "github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/api/rules/v1"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/rpc"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/messages"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func newServer(t *testing.T, opts Options) *Server {
+	t.Helper()
+	store := cache.NewMemoryCache()
+	manager, err := admin.NewManager(zerolog.Nop(), store, rules.DefaultRuleSet(), admin.Options{AckSecret: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewServer(zerolog.Nop(), manager, messages.Default(), opts)
+}
+
+func newClient(t *testing.T) rulesv1.RulesServiceClient {
+	t.Helper()
+	return newClientWith(t, Options{})
+}
+
+func newClientWith(t *testing.T, opts Options) rulesv1.RulesServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := rpc.NewServer()
+	rulesv1.RegisterRulesServiceServer(srv, newServer(t, opts))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := rpc.Dial("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rulesv1.NewRulesServiceClient(conn)
+}
+
+func selection(layout string) *configuratorv1.Selection {
+	return configuratorv1.FromSelection(configurator.Selection{
+		ConfigurationID: "cfg-" + layout,
+		Module:          "galley",
+		Layout:          layout,
+		Finish:          "matte",
+		Dimensions:      configurator.Dimensions{LengthMM: 4200, HeightMM: 900},
+		Options:         []configurator.SelectionOption{{ID: "island-counter", Quantity: 1}},
+	})
+}
+
+func TestValidate(t *testing.T) {
+	client := newClient(t)
+	resp, err := client.Validate(context.Background(), &rulesv1.ValidateRequest{Selection: selection("linear"), Fixes: true, AcceptLanguage: "de-DE,de;q=0.9"})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !resp.Blocking || resp.ConfigurationId != "cfg-linear" || resp.Fingerprint == "" || resp.Locale != "de" {
+		t.Fatalf("unexpected verdict: %v", resp)
+	}
+	if len(resp.Violations) == 0 || resp.Violations[0].Message == "" {
+		t.Fatalf("expected localized violations, got %v", resp.Violations)
+	}
+	if len(resp.Fixes) == 0 {
+		t.Fatalf("expected fixes for a blocked selection")
+	}
+
+	// The gRPC verdict reads back as the same result the HTTP client returns.
+	if res := rulesv1.ToResult(resp); !res.Blocking || len(res.Violations) != len(resp.Violations) || len(res.Fixes) != len(resp.Fixes) {
+		t.Fatalf("unexpected converted result: %+v", res)
+	}
+
+	_, err = client.Validate(context.Background(), &rulesv1.ValidateRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for an empty selection, got %v", err)
+	}
+}
+
+func TestBatchValidate(t *testing.T) {
+	client := newClient(t)
+	resp, err := client.BatchValidate(context.Background(), &rulesv1.BatchValidateRequest{Requests: []*rulesv1.ValidateRequest{
+		{Selection: selection("linear")},
+		{},
+		{Selection: selection("island")},
+	}})
+	if err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	if got := resp.Results[0].GetResponse(); got == nil || !got.Blocking {
+		t.Fatalf("expected a blocking verdict first, got %v", resp.Results[0])
+	}
+	if got := resp.Results[1].GetError(); got == nil || codes.Code(got.Code) != codes.InvalidArgument {
+		t.Fatalf("expected the empty selection to fail alone, got %v", resp.Results[1])
+	}
+	if got := resp.Results[2].GetResponse(); got == nil || got.ConfigurationId != "cfg-island" {
+		t.Fatalf("expected the island selection last, got %v", resp.Results[2])
+	}
+
+	tooMany := make([]*rulesv1.ValidateRequest, maxBatch+1)
+	if _, err := client.BatchValidate(context.Background(), &rulesv1.BatchValidateRequest{Requests: tooMany}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for an oversized batch, got %v", err)
+	}
+}
+
+func TestChannelComesFromTheCallersKey(t *testing.T) {
+	channelKeys, err := auth.ParseKeys("showroom-designer=showroom-key")
+	if err != nil {
+		t.Fatalf("parse keys: %v", err)
+	}
+	adminKeys, err := auth.ParseKeys("ops=admin-key")
+	if err != nil {
+		t.Fatalf("parse keys: %v", err)
+	}
+	client := newClientWith(t, Options{ChannelKeys: channelKeys, AdminKeys: adminKeys, DefaultChannel: "retail-web"})
+	validate := func(pairs ...string) (*rulesv1.ValidateResponse, error) {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(pairs...))
+		return client.Validate(ctx, &rulesv1.ValidateRequest{Selection: selection("linear")})
+	}
+
+	// Neither the selection nor x-channel metadata picks the policy.
+	sel := selection("linear")
+	sel.Channel = "admin"
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-channel", "admin"))
+	resp, err := client.Validate(ctx, &rulesv1.ValidateRequest{Selection: sel})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if resp.Policy != "retail-web" || !resp.Blocking {
+		t.Fatalf("expected the default channel's blocking verdict, got policy %q blocking %v", resp.Policy, resp.Blocking)
+	}
+
+	if resp, err = validate("authorization", "Bearer showroom-key"); err != nil || resp.Policy != "showroom-designer" {
+		t.Fatalf("expected the key's channel, got %v, %v", resp, err)
+	}
+	if resp, err = validate("authorization", "Bearer admin-key"); err != nil || resp.Policy != "retail-web" {
+		t.Fatalf("expected admin callers on the default channel, got %v, %v", resp, err)
+	}
+	if _, err = validate("authorization", "Bearer unknown-key"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected UNAUTHENTICATED for an unknown key, got %v", err)
+	}
+	_, err = client.BatchValidate(metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer unknown-key")),
+		&rulesv1.BatchValidateRequest{Requests: []*rulesv1.ValidateRequest{{Selection: selection("linear")}}})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected UNAUTHENTICATED for a batch with an unknown key, got %v", err)
+	}
+}
+
+func TestBatchValidateStopsWhenCancelled(t *testing.T) {
+	srv := newServer(t, Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := srv.BatchValidate(ctx, &rulesv1.BatchValidateRequest{Requests: []*rulesv1.ValidateRequest{
+		{Selection: selection("linear")},
+		{Selection: selection("island")},
+	}})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("expected CANCELED for a cancelled batch, got %v", err)
+	}
+}
+
+func TestValidateReportsTimeoutsAsContextErrors(t *testing.T) {
+	srv := newServer(t, Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	_, err := srv.Validate(ctx, &rulesv1.ValidateRequest{Selection: &configuratorv1.Selection{}})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DEADLINE_EXCEEDED for a validation past its deadline, got %v", err)
+	}
+	if _, err := srv.Validate(context.Background(), &rulesv1.ValidateRequest{Selection: &configuratorv1.Selection{}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for an invalid selection, got %v", err)
+	}
+}
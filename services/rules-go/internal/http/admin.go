@@ -0,0 +1,202 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/simulate"
+)
+
+// maxRuleSetBytes bounds uploaded drafts.
+const maxRuleSetBytes = 4 << 20
+
+func (h *handler) mountAdmin(r chi.Router) {
+	r.Post("/drafts", h.createDraft)
+	r.Get("/drafts/{id}", h.getDraft)
+	r.Post("/drafts/{id}/validate", h.checkDraft)
+	r.Get("/drafts/{id}/diff", h.diffDraft)
+	r.Post("/drafts/{id}/simulate", h.simulateDraft)
+	r.Post("/drafts/{id}/publish", h.publishDraft)
+	r.Get("/versions", h.listVersions)
+	r.Post("/versions/{version}/rollback", h.rollback)
+	r.Get("/audit", h.listAudit)
+	r.Get("/corpus", h.getCorpus)
+}
+
+// requireAdmin guards admin routes with per-actor bearer keys and records
+// the authenticated actor for the audit trail.
+func requireAdmin(keys auth.Keys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor, ok := keys.Lookup(auth.Bearer(r.Header.Get("Authorization")))
+			if !ok {
+				unauthorized(w)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), actor)))
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+}
+
+// actor names who made a change for the audit trail. It is the principal
+// requireAdmin authenticated, never a value the caller declares.
+func actor(r *http.Request) string {
+	actor, _ := auth.FromContext(r.Context())
+	return actor
+}
+
+func (h *handler) createDraft(w http.ResponseWriter, r *http.Request) {
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxRuleSetBytes))
+	if err != nil {
+		h.respondErr(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	set, err := admin.DecodeRuleSet(raw)
+	if err != nil {
+		h.respondErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	draft, err := h.manager.CreateDraft(r.Context(), set, actor(r))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	check, err := h.manager.Check(r.Context(), draft.ID)
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, map[string]any{"draft": draft, "check": check})
+}
+
+func (h *handler) getDraft(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.manager.Draft(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, draft)
+}
+
+func (h *handler) checkDraft(w http.ResponseWriter, r *http.Request) {
+	check, err := h.manager.Check(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, check)
+}
+
+func (h *handler) diffDraft(w http.ResponseWriter, r *http.Request) {
+	diff, err := h.manager.Diff(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, diff)
+}
+
+// simulateDraft replays the recorded corpus against the active set and the
+// draft.
+func (h *handler) simulateDraft(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.manager.Draft(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	corpus, err := h.recorder.Corpus(r.Context())
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	report, err := simulate.Replay(r.Context(), corpus, h.manager.Active(), draft.Set)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		h.respondErr(w, http.StatusServiceUnavailable, "simulation timed out")
+		return
+	}
+	if err != nil {
+		h.respondErr(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+func (h *handler) getCorpus(w http.ResponseWriter, r *http.Request) {
+	corpus, err := h.recorder.Corpus(r.Context())
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, corpus)
+}
+
+func (h *handler) publishDraft(w http.ResponseWriter, r *http.Request) {
+	record, err := h.manager.Publish(r.Context(), chi.URLParam(r, "id"), actor(r))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, record)
+}
+
+func (h *handler) listVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.manager.Versions(r.Context())
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]any{"active": h.manager.Engine().Version(), "versions": versions})
+}
+
+func (h *handler) rollback(w http.ResponseWriter, r *http.Request) {
+	record, err := h.manager.Rollback(r.Context(), chi.URLParam(r, "version"), actor(r))
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, record)
+}
+
+func (h *handler) listAudit(w http.ResponseWriter, r *http.Request) {
+	records, err := h.manager.Audit(r.Context())
+	if err != nil {
+		h.respondAdminErr(w, err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]any{"records": records})
+}
+
+func (h *handler) respondAdminErr(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, admin.ErrNotFound):
+		h.respondErr(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, admin.ErrInvalid):
+		h.respondErr(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, admin.ErrConflict):
+		h.respondErr(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error().Err(err).Msg("rules admin failed")
+		h.respondErr(w, http.StatusInternalServerError, "rules admin failed")
+	}
+}
+
+func (h *handler) respondJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.log.Error().Err(err).Msg("encode response failed")
+	}
+}
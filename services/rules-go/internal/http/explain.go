@@ -0,0 +1,49 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+type explainRequest struct {
+	Selection rules.Selection `json:"selection"`
+	Change    rules.Change    `json:"change"`
+}
+
+func (h *handler) explain(w http.ResponseWriter, r *http.Request) {
+	var req explainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondErr(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	req.Selection.Channel = h.channel(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	exp, err := h.manager.Engine().Explain(ctx, req.Selection, req.Change)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		h.respondErr(w, http.StatusServiceUnavailable, "explanation timed out")
+		return
+	}
+	if err != nil {
+		h.respondErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	locale := h.messages.Negotiate(req.Selection.Locale, r.Header.Get("Accept-Language"))
+	for i := range exp.Reasons {
+		exp.Reasons[i].Violation.Message = h.messages.Render(locale, exp.Reasons[i].Violation)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", locale)
+	if err := json.NewEncoder(w).Encode(exp); err != nil {
+		h.log.Error().Err(err).Msg("encode response failed")
+	}
+}
@@ -13,10 +13,35 @@ import (
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/messages"
 	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/session"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/simulate"
 )
 
-func NewHTTPHandler(log zerolog.Logger, engine *rules.Engine) http.Handler {
+// maxRequestBytes bounds the selection payloads of the public routes.
+const maxRequestBytes = 256 << 10
+
+// Options carries the optional parts of the HTTP surface.
+type Options struct {
+	// AdminKeys maps admin bearer tokens to the actor recorded in the audit
+	// trail. The admin routes are mounted when any key is set.
+	AdminKeys auth.Keys
+	// Recorder samples validated selections for impact simulation.
+	Recorder *simulate.Recorder
+	// Sessions mounts incremental validation sessions when set.
+	Sessions *session.Store
+	// ChannelKeys maps API keys to the channel whose severity policy their
+	// callers get. The channel is never taken from the request itself.
+	ChannelKeys auth.Keys
+	// DefaultChannel is the policy for callers without a key.
+	DefaultChannel string
+}
+
+// NewHTTPHandler serves validation from the manager's active engine.
+func NewHTTPHandler(log zerolog.Logger, manager *admin.Manager, catalog *messages.Catalog, opts Options) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID, middleware.RealIP, middleware.Recoverer, middleware.Timeout(1500*time.Millisecond))
 	r.Use(otelhttp.NewMiddleware("rules-go-http"))
@@ -26,17 +51,78 @@ func NewHTTPHandler(log zerolog.Logger, engine *rules.Engine) http.Handler {
 		AllowedHeaders: []string{"*"},
 	}))
 
-	h := &handler{log: log, engine: engine}
+	h := &handler{log: log, manager: manager, messages: catalog, recorder: opts.Recorder, sessions: opts.Sessions, defaultChannel: opts.DefaultChannel}
 
 	r.Get("/healthz", h.health)
-	r.Post("/v1/rules/validate", h.validate)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate(opts.ChannelKeys, opts.AdminKeys), middleware.RequestSize(maxRequestBytes))
+		r.Post("/v1/rules/validate", h.validate)
+		r.Post("/v1/rules/explain", h.explain)
+		if opts.Sessions != nil {
+			r.Route("/v1/rules/sessions", h.mountSessions)
+		}
+	})
+	if opts.AdminKeys.Len() > 0 {
+		r.Route("/v1/rules/admin", func(r chi.Router) {
+			r.Use(requireAdmin(opts.AdminKeys))
+			h.mountAdmin(r)
+		})
+	}
 
 	return r
 }
 
 type handler struct {
-	log    zerolog.Logger
-	engine *rules.Engine
+	log      zerolog.Logger
+	manager  *admin.Manager
+	messages *messages.Catalog
+	recorder *simulate.Recorder
+	sessions *session.Store
+
+	defaultChannel string
+}
+
+// authenticate resolves an optional bearer API key to its channel. Callers
+// without a key get the default channel; an unknown key is rejected rather
+// than silently downgraded. An admin key marks an internal caller, which
+// keeps the default channel but may request traces.
+func authenticate(keys, adminKeys auth.Keys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := auth.Bearer(r.Header.Get("Authorization"))
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := adminKeys.Lookup(token); ok {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), internalKey{}, true)))
+				return
+			}
+			channel, ok := keys.Lookup(token)
+			if !ok {
+				unauthorized(w)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), channel)))
+		})
+	}
+}
+
+type internalKey struct{}
+
+// internal reports whether the caller presented an admin key.
+func internal(r *http.Request) bool {
+	ok, _ := r.Context().Value(internalKey{}).(bool)
+	return ok
+}
+
+// channel is the policy channel of the authenticated caller. Selections and
+// headers cannot choose it, so a client cannot opt into a laxer policy.
+func (h *handler) channel(r *http.Request) string {
+	if channel, ok := auth.FromContext(r.Context()); ok {
+		return channel
+	}
+	return h.defaultChannel
 }
 
 func (h *handler) health(w http.ResponseWriter, _ *http.Request) {
@@ -54,23 +140,56 @@ func (h *handler) validate(w http.ResponseWriter, r *http.Request) {
 	if sel.ConfigurationID == "" {
 		sel.ConfigurationID = uuid.NewString()
 	}
+	sel.Channel = h.channel(r)
 
 	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
 	defer cancel()
+	if wantsTrace(r) {
+		// Traces skip the cache and expose every rule, so they are reserved
+		// for internal callers.
+		if !internal(r) {
+			h.respondErr(w, http.StatusForbidden, "tracing requires an admin key")
+			return
+		}
+		ctx = rules.WithTrace(ctx)
+	}
 
-	result, err := h.engine.Validate(ctx, sel)
+	result, err := h.manager.Engine().Validate(ctx, sel)
 	if err != nil {
 		h.log.Warn().Err(err).Msg("rules validation failed")
 		h.respondErr(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.recorder.Record(sel)
+	if result.Blocking && r.URL.Query().Get("fixes") == "true" {
+		if result.Fixes, err = h.manager.Engine().Repairs(ctx, sel); err != nil {
+			h.log.Warn().Err(err).Msg("rules repair search failed")
+		}
+	}
+
+	// Results are cached locale-free; render text only after the lookup.
+	locale := h.messages.Negotiate(sel.Locale, r.Header.Get("Accept-Language"))
+	h.messages.Localize(&result, locale)
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", locale)
+	w.Header().Set("X-Configuration-Fingerprint", result.Fingerprint)
 	if err := json.NewEncoder(w).Encode(result); err != nil {
 		h.log.Error().Err(err).Msg("encode response failed")
 	}
 }
 
+// wantsTrace opts into per-rule tracing via ?trace=true or X-Rules-Trace.
+func wantsTrace(r *http.Request) bool {
+	for _, v := range []string{r.URL.Query().Get("trace"), r.Header.Get("X-Rules-Trace")} {
+		switch v {
+		case "1", "true", "on":
+			return true
+		}
+	}
+	return false
+}
+
 func (h *handler) respondErr(w http.ResponseWriter, status int, msg string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
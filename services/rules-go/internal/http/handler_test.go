@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/auth"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/admin"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/messages"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func newTestHandler(t *testing.T, opts Options) http.Handler {
+	t.Helper()
+	store := cache.NewMemoryCache()
+	manager, err := admin.NewManager(zerolog.Nop(), store, rules.DefaultRuleSet(), admin.Options{AckSecret: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewHTTPHandler(zerolog.Nop(), manager, messages.Default(), opts)
+}
+
+func postValidate(t *testing.T, h http.Handler, body, bearer string) (*httptest.ResponseRecorder, rules.ValidationResult) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/rules/validate", strings.NewReader(body))
+	req.Header.Set("X-Channel", "admin")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var res rules.ValidationResult
+	if rec.Code == http.StatusOK {
+		if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return rec, res
+}
+
+func TestChannelComesFromTheCallersKey(t *testing.T) {
+	keys, err := auth.ParseKeys("showroom-designer=showroom-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h := newTestHandler(t, Options{ChannelKeys: keys, DefaultChannel: "retail-web"})
+	body := `{"module":"galley","layout":"linear","finish":"matte","channel":"admin","options":[{"id":"island-counter","quantity":1}]}`
+
+	rec, res := postValidate(t, h, body, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	if res.Policy != "retail-web" || !res.Blocking {
+		t.Fatalf("expected the default channel despite channel=admin, got policy %q blocking %v", res.Policy, res.Blocking)
+	}
+
+	if _, res = postValidate(t, h, body, "showroom-key"); res.Policy != "showroom-designer" {
+		t.Fatalf("expected the key's channel, got %q", res.Policy)
+	}
+
+	if rec, _ = postValidate(t, h, body, "guessed-key"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for an unknown key, got %d", rec.Code)
+	}
+}
+
+func TestTracingRequiresTheAdminToken(t *testing.T) {
+	h := newTestHandler(t, Options{AdminKeys: auth.Keys{}.With("ops", "admin-token")})
+	body := `{"module":"galley","layout":"linear","finish":"matte","options":[{"id":"island-counter","quantity":1}]}`
+	trace := func(bearer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/rules/validate?trace=true", strings.NewReader(body))
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := trace(""); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for an anonymous trace, got %d", rec.Code)
+	}
+	rec := trace("admin-token")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var res rules.ValidationResult
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(res.Trace) == 0 {
+		t.Fatalf("expected an internal caller to get a trace")
+	}
+}
+
+func TestPublicRoutesLimitTheRequestBody(t *testing.T) {
+	h := newTestHandler(t, Options{DefaultChannel: "retail-web"})
+	padding := strings.Repeat(" ", maxRequestBytes)
+	selection := `{"module":"galley","layout":"linear","finish":"matte"}`
+	for path, body := range map[string]string{
+		"/v1/rules/validate": selection,
+		"/v1/rules/explain":  `{"selection":` + selection + `,"change":{"op":"add-option","option":"drawer-organizer"}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected a small body to pass, got %d: %s", path, rec.Code, rec.Body)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, path, strings.NewReader(padding+body))
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected an oversized body to be rejected, got %d", path, rec.Code)
+		}
+	}
+}
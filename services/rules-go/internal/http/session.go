@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/session"
+)
+
+func (h *handler) mountSessions(r chi.Router) {
+	r.Post("/", h.openSession)
+	r.Get("/{id}", h.getSession)
+	r.Post("/{id}/changes", h.applyChanges)
+}
+
+type sessionResponse struct {
+	ID       string                 `json:"id"`
+	Revision int                    `json:"revision"`
+	Result   rules.ValidationResult `json:"result"`
+}
+
+type changesRequest struct {
+	// Revision, when set, must match the session's current revision.
+	Revision int            `json:"revision,omitempty"`
+	Changes  []rules.Change `json:"changes"`
+}
+
+type changesResponse struct {
+	ID       string      `json:"id"`
+	Revision int         `json:"revision"`
+	Delta    rules.Delta `json:"delta"`
+}
+
+func (h *handler) openSession(w http.ResponseWriter, r *http.Request) {
+	var sel rules.Selection
+	if err := json.NewDecoder(r.Body).Decode(&sel); err != nil {
+		h.respondErr(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	sel.Channel = h.channel(r)
+	engine := h.manager.Engine()
+	sess, err := h.sessions.Open(r.Context(), engine, sel)
+	if err != nil {
+		h.respondSessionErr(w, err)
+		return
+	}
+	h.recorder.Record(sel)
+	h.respondSession(w, r, http.StatusCreated, engine, sess)
+}
+
+func (h *handler) getSession(w http.ResponseWriter, r *http.Request) {
+	engine := h.manager.Engine()
+	sess, err := h.sessions.Get(r.Context(), engine, chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondSessionErr(w, err)
+		return
+	}
+	h.respondSession(w, r, http.StatusOK, engine, sess)
+}
+
+func (h *handler) applyChanges(w http.ResponseWriter, r *http.Request) {
+	var req changesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondErr(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	sess, delta, err := h.sessions.Apply(r.Context(), h.manager.Engine(), chi.URLParam(r, "id"), req.Revision, req.Changes)
+	if err != nil {
+		h.respondSessionErr(w, err)
+		return
+	}
+
+	locale := h.messages.Negotiate(sess.Snapshot.Selection.Locale, r.Header.Get("Accept-Language"))
+	for _, list := range [][]rules.Violation{delta.Added, delta.Updated, delta.Removed} {
+		for i := range list {
+			list[i].Message = h.messages.Render(locale, list[i])
+		}
+	}
+	w.Header().Set("Content-Language", locale)
+	h.respondJSON(w, http.StatusOK, changesResponse{ID: sess.ID, Revision: sess.Revision, Delta: delta})
+}
+
+func (h *handler) respondSession(w http.ResponseWriter, r *http.Request, status int, engine *rules.Engine, sess session.Session) {
+	result := engine.Result(sess.Snapshot)
+	locale := h.messages.Negotiate(sess.Snapshot.Selection.Locale, r.Header.Get("Accept-Language"))
+	h.messages.Localize(&result, locale)
+	w.Header().Set("Content-Language", locale)
+	h.respondJSON(w, status, sessionResponse{ID: sess.ID, Revision: sess.Revision, Result: result})
+}
+
+func (h *handler) respondSessionErr(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, session.ErrNotFound):
+		h.respondErr(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, session.ErrInvalid):
+		h.respondErr(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, session.ErrConflict):
+		h.respondErr(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error().Err(err).Msg("rules session failed")
+		h.respondErr(w, http.StatusInternalServerError, "rules session failed")
+	}
+}
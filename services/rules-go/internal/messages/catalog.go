@@ -0,0 +1,226 @@
+// Package messages renders violation text from a per-locale catalog keyed by
+// violation code. Templates use an ICU MessageFormat subset ({name}, plural
+// and select) filled from the violation's params, so rule evaluation (and its
+// cache) stays locale-free.
+package messages
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+// DefaultLocale is used when negotiation finds no supported locale.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var embedded embed.FS
+
+// Catalog maps locale -> violation code -> compiled message template.
+type Catalog struct {
+	templates map[string]map[string]message
+	fallback  string
+}
+
+// Default returns the catalog built from the embedded translation files.
+func Default() *Catalog {
+	cat, err := Load(embedded, "locales")
+	if err != nil {
+		panic(fmt.Sprintf("embedded message catalog is invalid: %v", err))
+	}
+	return cat
+}
+
+// Load reads every <locale>.json file in dir. Each file is a flat object of
+// violation code to template; a code ending in ".*" matches any suffix.
+func Load(fsys fs.FS, dir string) (*Catalog, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	cat := &Catalog{templates: make(map[string]map[string]message), fallback: DefaultLocale}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		raw, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var sources map[string]string
+		if err := json.Unmarshal(raw, &sources); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		templates := make(map[string]message, len(sources))
+		for code, src := range sources {
+			msg, err := parseMessage(src)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", entry.Name(), code, err)
+			}
+			templates[code] = msg
+		}
+		cat.templates[strings.ToLower(strings.TrimSuffix(entry.Name(), ".json"))] = templates
+	}
+	if _, ok := cat.templates[cat.fallback]; !ok {
+		return nil, fmt.Errorf("fallback locale %s is missing", cat.fallback)
+	}
+	return cat, nil
+}
+
+// Locales lists the supported locales in sorted order.
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.templates))
+	for l := range c.templates {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Negotiate picks the best supported locale. An explicit locale (for example
+// the selection's own field) wins over the Accept-Language header; both fall
+// back from "de-AT" to "de" before giving up on the default locale.
+func (c *Catalog) Negotiate(explicit, acceptLanguage string) string {
+	if explicit != "" {
+		if l, ok := c.match(explicit); ok {
+			return l
+		}
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if l, ok := c.match(tag); ok {
+			return l
+		}
+	}
+	return c.fallback
+}
+
+func (c *Catalog) match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if _, ok := c.templates[tag]; ok {
+		return tag, true
+	}
+	if i := strings.Index(tag, "-"); i > 0 {
+		if _, ok := c.templates[tag[:i]]; ok {
+			return tag[:i], true
+		}
+	}
+	return "", false
+}
+
+// Render returns the localised text for a violation, falling back to the
+// default locale and finally to the engine's own message. Violations that
+// fold several offenders carry params["more"]; without it templates render
+// as for a single offender.
+func (c *Catalog) Render(locale string, v rules.Violation) string {
+	params := v.Params
+	if _, ok := params["more"]; !ok {
+		params = make(map[string]any, len(v.Params)+1)
+		for k, val := range v.Params {
+			params[k] = val
+		}
+		params["more"] = 0
+	}
+	for _, l := range []string{locale, c.fallback} {
+		if tmpl, ok := c.lookup(l, v.Code); ok {
+			var b strings.Builder
+			tmpl.render(&b, l, params, "#")
+			return b.String()
+		}
+	}
+	return v.Message
+}
+
+// Localize renders every violation message in place and records the locale.
+func (c *Catalog) Localize(res *rules.ValidationResult, locale string) {
+	for i := range res.Violations {
+		res.Violations[i].Message = c.Render(locale, res.Violations[i])
+	}
+	res.Locale = locale
+}
+
+func (c *Catalog) lookup(locale, code string) (message, bool) {
+	templates, ok := c.templates[locale]
+	if !ok {
+		return nil, false
+	}
+	if tmpl, ok := templates[code]; ok {
+		return tmpl, true
+	}
+	for key := code; ; {
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return nil, false
+		}
+		key = key[:i]
+		if tmpl, ok := templates[key+".*"]; ok {
+			return tmpl, true
+		}
+	}
+}
+
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	case float64:
+		// Params round-trip through the JSON cache as float64.
+		if val == math.Trunc(val) {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []string:
+		return strings.Join(val, ", ")
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, formatValue(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// parseAcceptLanguage returns language tags ordered by descending q-value.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	tags := make([]weighted, 0)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if parsed, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.tag)
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func TestNegotiatePrefersExplicitLocaleThenHeader(t *testing.T) {
+	cat := Default()
+	cases := []struct {
+		explicit, header, want string
+	}{
+		{"", "", "en"},
+		{"fr-CA", "de", "fr"},
+		{"", "es;q=0.9, de-AT;q=0.8, en;q=0.5", "de"},
+		{"pt", "ja, *", "en"},
+	}
+	for _, tc := range cases {
+		if got := cat.Negotiate(tc.explicit, tc.header); got != tc.want {
+			t.Fatalf("Negotiate(%q, %q) = %q, want %q", tc.explicit, tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestLocalizeRendersCachedResultsPerLocale(t *testing.T) {
+	cat := Default()
+	engine := rules.NewEngine(cache.NewMemoryCache(), time.Minute)
+	sel := rules.Selection{
+		Module:     "galley",
+		Layout:     "u-shape",
+		Finish:     "matte",
+		Dimensions: rules.Dimensions{LengthMM: 2000},
+	}
+
+	first, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cat.Localize(&first, "de")
+	if got, want := first.Violations[0].Message, "Der Grundriss u-shape benötigt eine Länge zwischen 3600 mm und 9600 mm"; got != want {
+		t.Fatalf("unexpected german message: %q", got)
+	}
+
+	second, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !second.Cached {
+		t.Fatalf("expected the second locale to reuse the cached result")
+	}
+	cat.Localize(&second, "en")
+	if got, want := second.Violations[0].Message, "The u-shape layout needs a length between 3600mm and 9600mm"; got != want {
+		t.Fatalf("unexpected english message: %q", got)
+	}
+	if second.Locale != "en" {
+		t.Fatalf("expected locale to be echoed, got %q", second.Locale)
+	}
+}
+
+func TestRenderFallsBackToEngineMessage(t *testing.T) {
+	v := rules.Violation{Code: "custom.rule", Message: "custom text"}
+	if got := Default().Render("fr", v); got != "custom text" {
+		t.Fatalf("expected engine message fallback, got %q", got)
+	}
+}
+
+func TestEveryLocaleCoversTheDefaultCodes(t *testing.T) {
+	cat := Default()
+	for code := range cat.templates[DefaultLocale] {
+		for _, locale := range cat.Locales() {
+			if _, ok := cat.templates[locale][code]; !ok {
+				t.Fatalf("locale %s is missing %s", locale, code)
+			}
+		}
+	}
+}
+
+func TestCatalogRendersPluralAndSelect(t *testing.T) {
+	cat := Default()
+	limit := rules.Violation{Code: "appliance.limit", Params: map[string]any{"limit": 1, "requested": 2}}
+	if got, want := cat.Render("en", limit), "Appliance upgrades are limited to 1 appliance (requested 2)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	opening := rules.Violation{Code: "room.opening.window", Params: map[string]any{"opening": "window", "wall": "north"}}
+	if got, want := cat.Render("de", opening), "Ein Element verdeckt ein Fenster an Wand north"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestCatalogRendersTheOffenderCount(t *testing.T) {
+	cat := Default()
+	engine := rules.NewEngine(nil, 0)
+	res, err := engine.Validate(context.Background(), rules.Selection{
+		Module: "galley",
+		Layout: "linear",
+		Options: []rules.SelectionOption{
+			{ID: "corner-carousel", Quantity: 1},
+			{ID: "pull-out-pantry", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := map[string]string{
+		"en": "corner-carousel is not available for the linear layout (and 1 more)",
+		"de": "corner-carousel ist für den Grundriss linear nicht verfügbar (und 1 weitere)",
+		"fr": "corner-carousel n'est pas disponible pour l'implantation linear (et 1 autre)",
+	}
+	for _, v := range res.Violations {
+		if v.Code != "layout.blocked" {
+			continue
+		}
+		for locale, want := range cases {
+			if got := cat.Render(locale, v); got != want {
+				t.Fatalf("%s: got %q, want %q", locale, got, want)
+			}
+		}
+		return
+	}
+	t.Fatalf("expected a layout.blocked violation, got %+v", res.Violations)
+}
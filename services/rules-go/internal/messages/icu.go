@@ -0,0 +1,226 @@
+package messages
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The catalog supports the ICU MessageFormat subset violation text needs:
+//
+//	{name}                                    param substitution
+//	{name, plural, =0 {...} one {...} other {...}}  plural, # is the number
+//	{name, select, door {...} other {...}}    select on a string param
+//
+// Cases may nest. Apostrophe quoting, offsets and number/date styles are not
+// supported; a template using them fails to load.
+
+// message is a compiled template.
+type message []part
+
+// part is a literal, a # inside a plural case, or an argument.
+type part struct {
+	text  string
+	hash  bool
+	arg   string
+	kind  string // "", "plural" or "select"
+	cases map[string]message
+}
+
+// parseMessage compiles an ICU template.
+func parseMessage(tmpl string) (message, error) {
+	p := &parser{src: tmpl}
+	msg, err := p.message(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected } at %d", p.pos)
+	}
+	return msg, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+// message reads parts until an unmatched } or the end of the template.
+func (p *parser) message(inPlural bool) (message, error) {
+	var msg message
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			msg = append(msg, part{text: text.String()})
+			text.Reset()
+		}
+	}
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '}':
+			flush()
+			return msg, nil
+		case c == '{':
+			flush()
+			arg, err := p.argument()
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, arg)
+		case c == '#' && inPlural:
+			flush()
+			msg = append(msg, part{hash: true})
+			p.pos++
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flush()
+	return msg, nil
+}
+
+// argument reads {name}, {name, plural, ...} or {name, select, ...}.
+func (p *parser) argument() (part, error) {
+	start := p.pos
+	p.pos++ // {
+	name := p.token(",}")
+	if name == "" {
+		return part{}, fmt.Errorf("empty argument at %d", start)
+	}
+	if p.eat('}') {
+		return part{arg: name}, nil
+	}
+	if !p.eat(',') {
+		return part{}, fmt.Errorf("unterminated argument %q", name)
+	}
+	kind := p.token(",}")
+	if kind != "plural" && kind != "select" {
+		return part{}, fmt.Errorf("argument %q: unsupported type %q", name, kind)
+	}
+	if !p.eat(',') {
+		return part{}, fmt.Errorf("argument %q: %s needs cases", name, kind)
+	}
+	cases := make(map[string]message)
+	for {
+		p.skipSpace()
+		if p.eat('}') {
+			break
+		}
+		selector := p.token("{}")
+		if selector == "" || !p.eat('{') {
+			return part{}, fmt.Errorf("argument %q: malformed case at %d", name, p.pos)
+		}
+		sub, err := p.message(kind == "plural")
+		if err != nil {
+			return part{}, err
+		}
+		if !p.eat('}') {
+			return part{}, fmt.Errorf("argument %q: unterminated case %q", name, selector)
+		}
+		cases[selector] = sub
+	}
+	if _, ok := cases["other"]; !ok {
+		return part{}, fmt.Errorf("argument %q: %s needs an other case", name, kind)
+	}
+	return part{arg: name, kind: kind, cases: cases}, nil
+}
+
+// token reads up to one of the stop characters or whitespace and trims it.
+func (p *parser) token(stop string) string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(stop, rune(p.src[p.pos])) && p.src[p.pos] != ' ' {
+		p.pos++
+	}
+	tok := p.src[start:p.pos]
+	p.skipSpace()
+	return tok
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) eat(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// render fills the template. Unknown simple placeholders are kept verbatim so
+// a missing param is visible rather than silently blank; plural and select
+// fall back to their other case.
+func (m message) render(b *strings.Builder, locale string, params map[string]any, hash string) {
+	for _, pt := range m {
+		switch {
+		case pt.hash:
+			b.WriteString(hash)
+		case pt.arg == "":
+			b.WriteString(pt.text)
+		case pt.kind == "":
+			if v, ok := params[pt.arg]; ok {
+				b.WriteString(formatValue(v))
+			} else {
+				b.WriteString("{" + pt.arg + "}")
+			}
+		case pt.kind == "plural":
+			n, ok := number(params[pt.arg])
+			if !ok {
+				pt.cases["other"].render(b, locale, params, hash)
+				continue
+			}
+			pt.pluralCase(locale, n).render(b, locale, params, formatValue(n))
+		default:
+			sub, ok := pt.cases[formatValue(params[pt.arg])]
+			if !ok {
+				sub = pt.cases["other"]
+			}
+			sub.render(b, locale, params, hash)
+		}
+	}
+}
+
+func (pt part) pluralCase(locale string, n float64) message {
+	if sub, ok := pt.cases["="+formatValue(n)]; ok {
+		return sub
+	}
+	if sub, ok := pt.cases[pluralCategory(locale, n)]; ok {
+		return sub
+	}
+	return pt.cases["other"]
+}
+
+// pluralCategory implements the CLDR cardinal rules for the shipped locales:
+// French treats 0 and 1 as "one", English and German only exactly 1.
+func pluralCategory(locale string, n float64) string {
+	switch locale {
+	case "fr":
+		if n >= 0 && n < 2 {
+			return "one"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+func number(v any) (float64, bool) {
+	switch val := v.(type) {
+	case int:
+		return float64(val), true
+	case float64:
+		return val, !math.IsNaN(val)
+	case string:
+		n, err := strconv.ParseFloat(val, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,68 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderString(t *testing.T, locale, tmpl string, params map[string]any) string {
+	t.Helper()
+	msg, err := parseMessage(tmpl)
+	if err != nil {
+		t.Fatalf("parse %q: %v", tmpl, err)
+	}
+	var b strings.Builder
+	msg.render(&b, locale, params, "#")
+	return b.String()
+}
+
+func TestPluralPicksLocaleCategory(t *testing.T) {
+	tmpl := "{n, plural, =0 {none} one {# unit} other {# units}}"
+	cases := []struct {
+		locale string
+		n      any
+		want   string
+	}{
+		{"en", 0, "none"},
+		{"en", 1, "1 unit"},
+		{"en", float64(3), "3 units"},
+		{"de", 1.5, "1.5 units"},
+		{"fr", 1.5, "1.5 unit"},
+		{"en", "x", "# units"},
+	}
+	for _, tc := range cases {
+		if got := renderString(t, tc.locale, tmpl, map[string]any{"n": tc.n}); got != tc.want {
+			t.Fatalf("%s %v: got %q, want %q", tc.locale, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestSelectAndNestedArguments(t *testing.T) {
+	tmpl := "{kind, select, door {a door on {wall}} other {an opening}} ({count, plural, one {# unit} other {# units}})"
+	got := renderString(t, "en", tmpl, map[string]any{"kind": "door", "wall": "north", "count": 2})
+	if want := "a door on north (2 units)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	got = renderString(t, "en", tmpl, map[string]any{"kind": "hatch", "count": 1})
+	if want := "an opening (1 unit)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got := renderString(t, "en", "missing {param}", nil); got != "missing {param}" {
+		t.Fatalf("expected unknown placeholders kept verbatim, got %q", got)
+	}
+}
+
+func TestParseRejectsMalformedTemplates(t *testing.T) {
+	for _, tmpl := range []string{
+		"{}",
+		"{n, number}",
+		"{n, plural, one {#}}",
+		"{n, select, other {x}",
+		"{n, plural, one {x} other {y}",
+		"stray }",
+	} {
+		if _, err := parseMessage(tmpl); err == nil {
+			t.Fatalf("expected %q to be rejected", tmpl)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Finding kinds reported by Analyze.
+const (
+	FindingContradiction   = "contradiction"
+	FindingAlwaysTriggered = "always-triggered"
+	FindingNeverTriggered  = "never-triggered"
+	FindingShadowed        = "shadowed"
+	FindingBandConflict    = "band-conflict"
+	FindingUnreachable     = "unreachable"
+)
+
+// Domain lists the layouts, finishes and options the analyzer probes. Rules
+// referencing values outside the domain can never fire.
+type Domain struct {
+	Layouts  []string `json:"layouts"`
+	Finishes []string `json:"finishes"`
+	Options  []string `json:"options"`
+}
+
+// DefaultDomain mirrors the values the configurator currently offers. Options
+// come from the shared option catalog.
+func DefaultDomain() Domain {
+	return Domain{
+		Layouts:  []string{"linear", "l-shape", "u-shape", "island"},
+		Finishes: []string{"matte", "gloss", "stainless", "wood-grain"},
+		Options:  optionCatalog.IDs(),
+	}
+}
+
+// Finding is a single problem detected in a rule set.
+type Finding struct {
+	Kind     string   `json:"kind"`
+	Severity string   `json:"severity"`
+	Rules    []string `json:"rules,omitempty"`
+	Subject  string   `json:"subject,omitempty"`
+	Pack     string   `json:"pack,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Report collects the findings for one rule set.
+type Report struct {
+	Version  string    `json:"version"`
+	Findings []Finding `json:"findings"`
+}
+
+// HasErrors reports whether any finding would make part of the catalogue
+// impossible to configure.
+func (r Report) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// Analyze statically inspects a rule set for contradictions, dead or
+// always-firing rules, shadowed rules, conflicting dimension bands and
+// options or layouts no valid configuration can reach. Cost is
+// O(r² + r·l·f·o) for r rules over the domain's layouts, finishes and options.
+// Each pack is analyzed layered on the base rules and only reports findings
+// the base rules do not already have.
+func Analyze(set RuleSet, domain Domain) Report {
+	findings := analyzeRules(set.Rules, domain)
+	seen := make(map[string]struct{}, len(findings))
+	for _, f := range findings {
+		seen[f.key()] = struct{}{}
+	}
+	for _, p := range set.Packs {
+		layered := make([]Rule, 0, len(set.Rules)+len(p.Rules))
+		layered = append(layered, set.Rules...)
+		layered = append(layered, p.Rules...)
+		for _, f := range analyzeRules(layered, domain) {
+			if _, dup := seen[f.key()]; dup {
+				continue
+			}
+			f.Pack = p.ID
+			findings = append(findings, f)
+		}
+	}
+	return Report{Version: set.Version, Findings: findings}
+}
+
+func (f Finding) key() string {
+	return f.Kind + "\x00" + strings.Join(f.Rules, ",") + "\x00" + f.Subject + "\x00" + f.Message
+}
+
+func analyzeRules(rules []Rule, domain Domain) []Finding {
+	expanded := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		expanded = append(expanded, r.expand())
+	}
+	a := &analyzer{
+		rules:    expanded,
+		domain:   domain,
+		layouts:  toSet(domain.Layouts),
+		finishes: toSet(domain.Finishes),
+		options:  toSet(domain.Options),
+		findings: make([]Finding, 0),
+	}
+	a.checkTriggers()
+	a.checkContradictions()
+	a.checkShadowing()
+	a.checkBands()
+	a.checkReachability()
+	return a.findings
+}
+
+type analyzer struct {
+	rules    []Rule
+	domain   Domain
+	layouts  map[string]struct{}
+	finishes map[string]struct{}
+	options  map[string]struct{}
+	findings []Finding
+}
+
+func (a *analyzer) add(f Finding) {
+	a.findings = append(a.findings, f)
+}
+
+func (a *analyzer) checkTriggers() {
+	for _, r := range a.rules {
+		never := ""
+		always := ""
+		switch r.Kind {
+		case RuleRequireLayout:
+			if !contains(a.options, r.Options[0]) {
+				never = fmt.Sprintf("option %s is not in the catalogue", r.Options[0])
+			} else if !contains(a.layouts, r.Layout) {
+				always = fmt.Sprintf("fires whenever %s is selected because layout %s does not exist", r.Options[0], r.Layout)
+			}
+		case RuleForbidOptions:
+			if !contains(a.layouts, r.Layout) {
+				never = fmt.Sprintf("layout %s is not in the catalogue", r.Layout)
+			} else if !containsAny(a.options, r.Options) {
+				never = fmt.Sprintf("none of %s are in the catalogue", strings.Join(r.Options, ", "))
+			}
+		case RuleFinishOnly:
+			if !contains(a.options, r.Options[0]) {
+				never = fmt.Sprintf("option %s is not in the catalogue", r.Options[0])
+			} else if !containsAny(a.finishes, r.Finishes) {
+				always = fmt.Sprintf("fires whenever %s is selected because no allowed finish exists", r.Options[0])
+			}
+		case RuleDimensionBand:
+			if !contains(a.layouts, r.Layout) {
+				never = fmt.Sprintf("layout %s is not in the catalogue", r.Layout)
+			} else if r.MinMM > r.MaxMM {
+				always = fmt.Sprintf("band %d-%dmm is empty so every measured %s layout fails", r.MinMM, r.MaxMM, r.Layout)
+			}
+		case RuleMaxAppliances:
+			if !containsAny(a.options, r.Options) {
+				never = fmt.Sprintf("none of %s are in the catalogue", strings.Join(r.Options, ", "))
+			} else if r.Limit < 0 {
+				always = fmt.Sprintf("limit %d is below zero so every configuration fails", r.Limit)
+			}
+		}
+		if never != "" {
+			a.add(Finding{Kind: FindingNeverTriggered, Severity: "warning", Rules: []string{r.ID}, Message: never})
+		}
+		if always != "" {
+			severity := "warning"
+			if r.effectiveSeverity() == "error" {
+				severity = "error"
+			}
+			a.add(Finding{Kind: FindingAlwaysTriggered, Severity: severity, Rules: []string{r.ID}, Message: always})
+		}
+	}
+}
+
+func (a *analyzer) checkContradictions() {
+	for i, x := range a.rules {
+		if x.effectiveSeverity() != "error" {
+			continue
+		}
+		for j, y := range a.rules {
+			if i == j || y.effectiveSeverity() != "error" {
+				continue
+			}
+			switch {
+			case x.Kind == RuleRequireLayout && y.Kind == RuleForbidOptions:
+				if x.Layout == y.Layout && containsAny(toSet(y.Options), x.Options) {
+					a.add(Finding{
+						Kind:     FindingContradiction,
+						Severity: "error",
+						Rules:    []string{x.ID, y.ID},
+						Subject:  x.Options[0],
+						Message:  fmt.Sprintf("%s requires %s layout but %s layout forbids it", x.Options[0], x.Layout, y.Layout),
+					})
+				}
+			case x.Kind == RuleRequireLayout && y.Kind == RuleRequireLayout && i < j:
+				if x.Options[0] == y.Options[0] && x.Layout != y.Layout {
+					a.add(Finding{
+						Kind:     FindingContradiction,
+						Severity: "error",
+						Rules:    []string{x.ID, y.ID},
+						Subject:  x.Options[0],
+						Message:  fmt.Sprintf("%s requires both %s and %s layouts", x.Options[0], x.Layout, y.Layout),
+					})
+				}
+			case x.Kind == RuleFinishOnly && y.Kind == RuleFinishOnly && i < j:
+				if x.Options[0] == y.Options[0] && !containsAny(toSet(x.Finishes), y.Finishes) {
+					a.add(Finding{
+						Kind:     FindingContradiction,
+						Severity: "error",
+						Rules:    []string{x.ID, y.ID},
+						Subject:  x.Options[0],
+						Message:  fmt.Sprintf("%s has no finish allowed by both rules", x.Options[0]),
+					})
+				}
+			}
+		}
+	}
+}
+
+// checkShadowing flags rules that can only fire when an earlier rule of at
+// least the same severity already fires, so they never change the outcome.
+func (a *analyzer) checkShadowing() {
+	for j, later := range a.rules {
+		for i := 0; i < j; i++ {
+			earlier := a.rules[i]
+			if earlier.Kind != later.Kind || severityRank(earlier.effectiveSeverity()) < severityRank(later.effectiveSeverity()) {
+				continue
+			}
+			if shadows(earlier, later) {
+				a.add(Finding{
+					Kind:     FindingShadowed,
+					Severity: "warning",
+					Rules:    []string{later.ID, earlier.ID},
+					Message:  fmt.Sprintf("%s never fires without %s also firing", later.ID, earlier.ID),
+				})
+				break
+			}
+		}
+	}
+}
+
+func shadows(earlier, later Rule) bool {
+	switch later.Kind {
+	case RuleRequireLayout:
+		return earlier.Options[0] == later.Options[0] && earlier.Layout == later.Layout
+	case RuleForbidOptions:
+		return earlier.Layout == later.Layout && subset(later.Options, earlier.Options)
+	case RuleFinishOnly:
+		return earlier.Options[0] == later.Options[0] && subset(earlier.Finishes, later.Finishes)
+	case RuleDimensionBand:
+		return earlier.Layout == later.Layout && earlier.MinMM >= later.MinMM && earlier.MaxMM <= later.MaxMM
+	case RuleMaxAppliances:
+		return sameSet(earlier.Options, later.Options) && earlier.Limit <= later.Limit
+	case RuleWallFit, RuleOpenings:
+		return true
+	case RuleCeilingFit, RuleWalkway:
+		return earlier.MinMM >= later.MinMM
+	}
+	return false
+}
+
+// checkBands looks for dimension bands on the same layout that leave no valid
+// length or that only partially overlap, which usually signals a typo.
+func (a *analyzer) checkBands() {
+	byLayout := make(map[string][]Rule)
+	layouts := make([]string, 0)
+	for _, r := range a.rules {
+		if r.Kind != RuleDimensionBand || r.effectiveSeverity() != "error" || r.MinMM > r.MaxMM {
+			continue
+		}
+		if _, ok := byLayout[r.Layout]; !ok {
+			layouts = append(layouts, r.Layout)
+		}
+		byLayout[r.Layout] = append(byLayout[r.Layout], r)
+	}
+
+	for _, layout := range layouts {
+		bands := byLayout[layout]
+		if len(bands) < 2 {
+			continue
+		}
+		lo, hi := bands[0].MinMM, bands[0].MaxMM
+		ids := []string{bands[0].ID}
+		for _, b := range bands[1:] {
+			lo = maxInt(lo, b.MinMM)
+			hi = minInt(hi, b.MaxMM)
+			ids = append(ids, b.ID)
+		}
+		if lo > hi {
+			a.add(Finding{
+				Kind:     FindingBandConflict,
+				Severity: "error",
+				Rules:    ids,
+				Subject:  layout,
+				Message:  fmt.Sprintf("no length satisfies every band for %s layout", layout),
+			})
+			continue
+		}
+		for i := range bands {
+			for j := i + 1; j < len(bands); j++ {
+				x, y := bands[i], bands[j]
+				if shadows(x, y) || shadows(y, x) {
+					continue
+				}
+				a.add(Finding{
+					Kind:     FindingBandConflict,
+					Severity: "warning",
+					Rules:    []string{x.ID, y.ID},
+					Subject:  layout,
+					Message: fmt.Sprintf("bands %d-%dmm and %d-%dmm partially overlap; effective band for %s is %d-%dmm",
+						x.MinMM, x.MaxMM, y.MinMM, y.MaxMM, layout, lo, hi),
+				})
+			}
+		}
+	}
+}
+
+// checkReachability probes every layout × finish combination to find options
+// and layouts that no configuration free of blocking violations can include.
+func (a *analyzer) checkReachability() {
+	compiled := make([]constraint, 0, len(a.rules))
+	for _, r := range a.rules {
+		compiled = append(compiled, r.compile())
+	}
+	passes := func(sel Selection) bool {
+		for _, c := range compiled {
+			if v, violated := c.evaluate(newFacts(sel)); violated && strings.EqualFold(v.Severity, "error") {
+				return false
+			}
+		}
+		return true
+	}
+	finishes := a.domain.Finishes
+	if len(finishes) == 0 {
+		finishes = []string{""}
+	}
+
+	for _, layout := range a.domain.Layouts {
+		ok := false
+		for _, finish := range finishes {
+			if passes(Selection{Layout: layout, Finish: finish}) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			a.add(Finding{
+				Kind:     FindingUnreachable,
+				Severity: "error",
+				Subject:  layout,
+				Message:  fmt.Sprintf("layout %s cannot appear in any valid configuration", layout),
+			})
+		}
+	}
+
+	for _, option := range a.domain.Options {
+		ok := false
+		for _, layout := range a.domain.Layouts {
+			for _, finish := range finishes {
+				sel := Selection{Layout: layout, Finish: finish, Options: []SelectionOption{{ID: option, Quantity: 1}}}
+				if passes(sel) {
+					ok = true
+					break
+				}
+			}
+			if ok {
+				break
+			}
+		}
+		if !ok {
+			a.add(Finding{
+				Kind:     FindingUnreachable,
+				Severity: "error",
+				Rules:    a.rulesMentioning(option),
+				Subject:  option,
+				Message:  fmt.Sprintf("option %s cannot appear in any valid configuration", option),
+			})
+		}
+	}
+}
+
+func (a *analyzer) rulesMentioning(option string) []string {
+	ids := make([]string, 0)
+	for _, r := range a.rules {
+		for _, id := range r.Options {
+			if id == option {
+				ids = append(ids, r.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "error":
+		return 2
+	case "warning":
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+func contains(set map[string]struct{}, v string) bool {
+	_, ok := set[v]
+	return ok
+}
+
+func containsAny(set map[string]struct{}, values []string) bool {
+	for _, v := range values {
+		if contains(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func subset(inner, outer []string) bool {
+	set := toSet(outer)
+	for _, v := range inner {
+		if !contains(set, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameSet(a, b []string) bool {
+	x := append([]string(nil), a...)
+	y := append([]string(nil), b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	return strings.Join(x, "\x00") == strings.Join(y, "\x00")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
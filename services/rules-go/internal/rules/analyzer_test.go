@@ -0,0 +1,81 @@
+package rules
+
+import "testing"
+
+func TestAnalyzeDefaultRuleSetIsClean(t *testing.T) {
+	report := Analyze(DefaultRuleSet(), DefaultDomain())
+	if report.HasErrors() {
+		t.Fatalf("built-in rules should not have blocking findings: %+v", report.Findings)
+	}
+}
+
+func TestAnalyzeDetectsContradictionAndUnreachableOption(t *testing.T) {
+	set := RuleSet{
+		Version: "test",
+		Rules: []Rule{
+			{ID: "carousel-needs-l", Kind: RuleRequireLayout, Layout: "l-shape", Options: []string{"corner-carousel"}},
+			{ID: "l-forbids-carousel", Kind: RuleForbidOptions, Layout: "l-shape", Options: []string{"corner-carousel"}},
+		},
+	}
+
+	report := Analyze(set, DefaultDomain())
+	if !hasFinding(report, FindingContradiction, "corner-carousel") {
+		t.Fatalf("expected contradiction finding, got %+v", report.Findings)
+	}
+	if !hasFinding(report, FindingUnreachable, "corner-carousel") {
+		t.Fatalf("expected corner-carousel to be unreachable, got %+v", report.Findings)
+	}
+	if !report.HasErrors() {
+		t.Fatalf("contradiction should be reported as an error")
+	}
+}
+
+func TestAnalyzeDetectsBandConflictsAndShadowing(t *testing.T) {
+	set := RuleSet{
+		Version: "test",
+		Rules: []Rule{
+			{ID: "u-short", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: 2000, MaxMM: 3000},
+			{ID: "u-long", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: 4000, MaxMM: 9000},
+			{ID: "island-narrow", Kind: RuleDimensionBand, Layout: "island", MinMM: 4200, MaxMM: 9000},
+			{ID: "island-wide", Kind: RuleDimensionBand, Layout: "island", MinMM: 4000, MaxMM: 12000},
+		},
+	}
+
+	report := Analyze(set, DefaultDomain())
+	if !hasFinding(report, FindingBandConflict, "u-shape") {
+		t.Fatalf("expected disjoint u-shape bands to conflict, got %+v", report.Findings)
+	}
+	if !hasFinding(report, FindingShadowed, "") {
+		t.Fatalf("expected island-wide to be shadowed by island-narrow, got %+v", report.Findings)
+	}
+}
+
+func TestAnalyzeDetectsNeverAndAlwaysTriggeredRules(t *testing.T) {
+	set := RuleSet{
+		Version: "test",
+		Rules: []Rule{
+			{ID: "typo", Kind: RuleRequireLayout, Layout: "island", Options: []string{"islnd-counter"}},
+			{ID: "negative-limit", Kind: RuleMaxAppliances, Options: []string{"range-upgrade"}, Limit: -1, Severity: "error"},
+		},
+	}
+
+	report := Analyze(set, DefaultDomain())
+	if !hasFinding(report, FindingNeverTriggered, "") {
+		t.Fatalf("expected unknown option to be reported as never triggered, got %+v", report.Findings)
+	}
+	if !hasFinding(report, FindingAlwaysTriggered, "") {
+		t.Fatalf("expected negative limit to be reported as always triggered, got %+v", report.Findings)
+	}
+	if !hasFinding(report, FindingUnreachable, "linear") {
+		t.Fatalf("expected every layout to be unreachable, got %+v", report.Findings)
+	}
+}
+
+func hasFinding(report Report, kind, subject string) bool {
+	for _, f := range report.Findings {
+		if f.Kind == kind && (subject == "" || f.Subject == subject) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Cache keys come from the order-insensitive fingerprint, but violation
+// paths index into the submitted option list. Cached results therefore store
+// option paths against the canonical order (options sorted by ID and
+// quantity, as the fingerprint sorts them) and each hit maps them back onto
+// the caller's order.
+
+// canonicalOrder returns the request index of each option in canonical order.
+func canonicalOrder(sel Selection) []int {
+	order := make([]int, len(sel.Options))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := sel.Options[order[a]], sel.Options[order[b]]
+		if x.ID == y.ID {
+			return x.Quantity < y.Quantity
+		}
+		return x.ID < y.ID
+	})
+	return order
+}
+
+// toCanonical returns a copy of the violations with option paths rewritten
+// from request indices to canonical positions.
+func toCanonical(sel Selection, violations []Violation) []Violation {
+	order := canonicalOrder(sel)
+	position := make([]int, len(order))
+	for pos, idx := range order {
+		position[idx] = pos
+	}
+	return remapOptionPaths(violations, position)
+}
+
+// fromCanonical returns a copy of the violations with option paths rewritten
+// from canonical positions to the selection's own indices.
+func fromCanonical(sel Selection, violations []Violation) []Violation {
+	return remapOptionPaths(violations, canonicalOrder(sel))
+}
+
+func remapOptionPaths(violations []Violation, index []int) []Violation {
+	out := make([]Violation, len(violations))
+	for i, v := range violations {
+		if v.Paths == nil {
+			out[i] = v
+			continue
+		}
+		paths := make([]string, len(v.Paths))
+		for j, p := range v.Paths {
+			paths[j] = remapOptionPath(p, index)
+		}
+		v.Paths = paths
+		out[i] = v
+	}
+	return out
+}
+
+func remapOptionPath(path string, index []int) string {
+	const prefix = "/options/"
+	if !strings.HasPrefix(path, prefix) {
+		return path
+	}
+	token, rest, _ := strings.Cut(path[len(prefix):], "/")
+	n, err := strconv.Atoi(token)
+	if err != nil || n < 0 || n >= len(index) {
+		return path
+	}
+	if rest != "" {
+		rest = "/" + rest
+	}
+	return prefix + strconv.Itoa(index[n]) + rest
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Change operations a client can apply to a selection.
+const (
+	OpAddOption    = "add-option"
+	OpRemoveOption = "remove-option"
+	OpSetLayout    = "set-layout"
+	OpSetFinish    = "set-finish"
+)
+
+// Change is a single edit to a selection, such as adding an option or
+// switching layout.
+type Change struct {
+	Op       string `json:"op"`
+	Option   string `json:"option,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+	Layout   string `json:"layout,omitempty"`
+	Finish   string `json:"finish,omitempty"`
+}
+
+// Validate checks the change carries the field its operation needs.
+func (c Change) Validate() error {
+	switch c.Op {
+	case OpAddOption, OpRemoveOption:
+		if c.Option == "" {
+			return fmt.Errorf("%s needs an option", c.Op)
+		}
+		if c.Quantity < 0 {
+			return errors.New("quantity must be >= 0")
+		}
+	case OpSetLayout:
+		if c.Layout == "" {
+			return errors.New("set-layout needs a layout")
+		}
+	case OpSetFinish:
+		if c.Finish == "" {
+			return errors.New("set-finish needs a finish")
+		}
+	default:
+		return fmt.Errorf("unknown op %q", c.Op)
+	}
+	return nil
+}
+
+// Apply returns a copy of the selection with the change made. Adding an
+// option that is already selected sets its quantity.
+func (c Change) Apply(sel Selection) Selection {
+	options := make([]SelectionOption, 0, len(sel.Options)+1)
+	switch c.Op {
+	case OpAddOption:
+		qty := c.Quantity
+		if qty == 0 {
+			qty = 1
+		}
+		found := false
+		for _, opt := range sel.Options {
+			if opt.ID == c.Option {
+				opt.Quantity = qty
+				found = true
+			}
+			options = append(options, opt)
+		}
+		if !found {
+			options = append(options, SelectionOption{ID: c.Option, Quantity: qty})
+		}
+		sel.Options = options
+	case OpRemoveOption:
+		for _, opt := range sel.Options {
+			if opt.ID != c.Option {
+				options = append(options, opt)
+			}
+		}
+		sel.Options = options
+	case OpSetLayout:
+		sel.Layout = c.Layout
+	case OpSetFinish:
+		sel.Finish = c.Finish
+	}
+	return sel
+}
+
+// target identifies what a change edits, so two changes to the same field
+// are never combined.
+func (c Change) target() string {
+	switch c.Op {
+	case OpAddOption, OpRemoveOption:
+		return "option:" + c.Option
+	case OpSetLayout:
+		return "layout"
+	case OpSetFinish:
+		return "finish"
+	}
+	return c.Op
+}
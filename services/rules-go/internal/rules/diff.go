@@ -0,0 +1,135 @@
+package rules
+
+import "reflect"
+
+// Change kinds reported by DiffRuleSets.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// RuleChange describes one rule that differs between two sets. Before is nil
+// for added rules and After is nil for removed ones.
+type RuleChange struct {
+	ID     string `json:"id"`
+	Pack   string `json:"pack,omitempty"`
+	Change string `json:"change"`
+	Before *Rule  `json:"before,omitempty"`
+	After  *Rule  `json:"after,omitempty"`
+}
+
+// EntryChange records an added, removed or changed pack or policy by ID.
+type EntryChange struct {
+	ID     string `json:"id"`
+	Change string `json:"change"`
+}
+
+// RuleSetDiff is the difference between two rule sets.
+type RuleSetDiff struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	Rules    []RuleChange  `json:"rules"`
+	Packs    []EntryChange `json:"packs"`
+	Policies []EntryChange `json:"policies"`
+}
+
+// Empty reports whether the two sets evaluate identically.
+func (d RuleSetDiff) Empty() bool {
+	return len(d.Rules) == 0 && len(d.Packs) == 0 && len(d.Policies) == 0
+}
+
+// DiffRuleSets compares rules by ID across the base set and its packs, and
+// packs and policies by ID. Changes are listed in the order of the target
+// set, followed by removals in the order of the source set.
+func DiffRuleSets(from, to RuleSet) RuleSetDiff {
+	diff := RuleSetDiff{
+		From:     from.Version,
+		To:       to.Version,
+		Rules:    make([]RuleChange, 0),
+		Packs:    make([]EntryChange, 0),
+		Policies: make([]EntryChange, 0),
+	}
+
+	type located struct {
+		pack string
+		rule Rule
+	}
+	flatten := func(set RuleSet) ([]string, map[string]located) {
+		order := make([]string, 0, len(set.Rules))
+		byID := make(map[string]located, len(set.Rules))
+		add := func(pack string, rules []Rule) {
+			for _, r := range rules {
+				order = append(order, r.ID)
+				byID[r.ID] = located{pack: pack, rule: r}
+			}
+		}
+		add("", set.Rules)
+		for _, p := range set.Packs {
+			add(p.ID, p.Rules)
+		}
+		return order, byID
+	}
+	fromOrder, fromRules := flatten(from)
+	toOrder, toRules := flatten(to)
+	for _, id := range toOrder {
+		after := toRules[id]
+		before, ok := fromRules[id]
+		switch {
+		case !ok:
+			diff.Rules = append(diff.Rules, RuleChange{ID: id, Pack: after.pack, Change: ChangeAdded, After: &after.rule})
+		case before.pack != after.pack || !reflect.DeepEqual(before.rule, after.rule):
+			diff.Rules = append(diff.Rules, RuleChange{ID: id, Pack: after.pack, Change: ChangeChanged, Before: &before.rule, After: &after.rule})
+		}
+	}
+	for _, id := range fromOrder {
+		if _, ok := toRules[id]; !ok {
+			before := fromRules[id]
+			diff.Rules = append(diff.Rules, RuleChange{ID: id, Pack: before.pack, Change: ChangeRemoved, Before: &before.rule})
+		}
+	}
+
+	// Pack rules are covered above, so packs only differ by their markets.
+	fromPacks := make(map[string][]string, len(from.Packs))
+	for _, p := range from.Packs {
+		fromPacks[p.ID] = p.Markets
+	}
+	toPacks := make(map[string]struct{}, len(to.Packs))
+	for _, p := range to.Packs {
+		toPacks[p.ID] = struct{}{}
+		markets, ok := fromPacks[p.ID]
+		switch {
+		case !ok:
+			diff.Packs = append(diff.Packs, EntryChange{ID: p.ID, Change: ChangeAdded})
+		case !reflect.DeepEqual(markets, p.Markets):
+			diff.Packs = append(diff.Packs, EntryChange{ID: p.ID, Change: ChangeChanged})
+		}
+	}
+	for _, p := range from.Packs {
+		if _, ok := toPacks[p.ID]; !ok {
+			diff.Packs = append(diff.Packs, EntryChange{ID: p.ID, Change: ChangeRemoved})
+		}
+	}
+
+	fromPolicies := make(map[string]SeverityPolicy, len(from.Policies))
+	for _, p := range from.Policies {
+		fromPolicies[p.ID] = p
+	}
+	toPolicies := make(map[string]struct{}, len(to.Policies))
+	for _, p := range to.Policies {
+		toPolicies[p.ID] = struct{}{}
+		before, ok := fromPolicies[p.ID]
+		switch {
+		case !ok:
+			diff.Policies = append(diff.Policies, EntryChange{ID: p.ID, Change: ChangeAdded})
+		case !reflect.DeepEqual(before, p):
+			diff.Policies = append(diff.Policies, EntryChange{ID: p.ID, Change: ChangeChanged})
+		}
+	}
+	for _, p := range from.Policies {
+		if _, ok := toPolicies[p.ID]; !ok {
+			diff.Policies = append(diff.Policies, EntryChange{ID: p.ID, Change: ChangeRemoved})
+		}
+	}
+	return diff
+}
@@ -2,90 +2,138 @@ package rules
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type constraint interface {
-	evaluate(sel Selection) (Violation, bool)
+	evaluate(sel *facts) (Violation, bool)
 }
 
-type constraintFunc func(sel Selection) (Violation, bool)
+type constraintFunc func(sel *facts) (Violation, bool)
 
-func (f constraintFunc) evaluate(sel Selection) (Violation, bool) {
+func (f constraintFunc) evaluate(sel *facts) (Violation, bool) {
 	return f(sel)
 }
 
-// Engine evaluates configuration rules deterministically (O(r)) where r is the
-// number of registered constraints.
+// Engine evaluates configuration rules deterministically. Rules are indexed by
+// the layout and option IDs that can trigger them, so a validation only runs
+// the rules relevant to the selection rather than every registered constraint.
 type Engine struct {
-	constraints []constraint
-	cache       cache.Cache
-	ttl         time.Duration
+	rules     ruleIndex
+	packs     []compiledPack
+	policies  map[string]SeverityPolicy
+	ackSecret []byte
+	version   string
+	cache     cache.Cache
+	ttl       time.Duration
 }
 
+// NewEngine builds an engine backed by the built-in rule set.
 func NewEngine(cache cache.Cache, ttl time.Duration) *Engine {
-	return &Engine{
-		constraints: []constraint{
-			requireLayoutForOption("island-counter", "island"),
-			forbidOptionsForLayout("linear", []string{"corner-carousel", "pull-out-pantry"}),
-			finishCompatibilityRule("glass-cabinet", []string{"gloss", "stainless"}),
-			dimensionBandRule("u-shape", 3600, 9600),
-			dimensionBandRule("island", 4200, 12000),
-			maxApplianceRule(3),
-		},
-		cache: cache,
-		ttl:   ttl,
+	engine, _ := NewEngineWithRules(DefaultRuleSet(), cache, ttl)
+	return engine
+}
+
+// NewEngineWithRules builds an engine from a declarative rule set.
+func NewEngineWithRules(set RuleSet, cache cache.Cache, ttl time.Duration) (*Engine, error) {
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	policies := make(map[string]SeverityPolicy, len(set.Policies))
+	for _, p := range set.Policies {
+		policies[strings.ToLower(p.ID)] = p
 	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &Engine{
+		rules:     newRuleIndex(compileRules(set.Rules)),
+		packs:     compilePacks(set.Packs),
+		policies:  policies,
+		ackSecret: secret,
+		version:   set.Version,
+		cache:     cache,
+		ttl:       ttl,
+	}, nil
 }
 
 func (e *Engine) Validate(ctx context.Context, sel Selection) (ValidationResult, error) {
-	if err := sel.Validate(); err != nil {
+	if err := validateSelection(sel); err != nil {
 		return ValidationResult{}, err
 	}
 
+	ctx, span := tracer.Start(ctx, "rules.validate", trace.WithAttributes(
+		attribute.String("rules.version", e.version),
+	))
+	defer span.End()
+
 	start := time.Now()
-	if e.cache != nil {
+	if e.cache != nil && !tracing(ctx) {
 		if res, ok := e.readFromCache(ctx, sel); ok {
+			span.SetAttributes(attribute.Bool("rules.cached", true))
+			res.ConfigurationID = sel.ConfigurationID
+			res.Violations = fromCanonical(sel, res.Violations)
+			e.applyPolicy(&res, sel)
 			res.Cached = true
 			res.LatencyMicros = time.Since(start).Microseconds()
 			return res, nil
 		}
 	}
 
-	violations := make([]Violation, 0)
-	blocking := false
-	for _, c := range e.constraints {
-		if violation, violated := c.evaluate(sel); violated {
-			violations = append(violations, violation)
-			if strings.EqualFold(violation.Severity, "error") {
-				blocking = true
-			}
-		}
-	}
-
-	result := ValidationResult{
-		ConfigurationID: sel.ConfigurationID,
-		Violations:      violations,
-		Blocking:        blocking,
-		LatencyMicros:   time.Since(start).Microseconds(),
-	}
+	result, ruleTrace := e.evaluate(ctx, sel)
+	result.LatencyMicros = time.Since(start).Microseconds()
 
 	if e.cache != nil && e.ttl > 0 {
-		if payload, err := json.Marshal(result); err == nil {
-			_ = e.cache.Set(ctx, sel.cacheKey(), string(payload), e.ttl)
+		cached := result
+		cached.Violations = toCanonical(sel, result.Violations)
+		if payload, err := json.Marshal(cached); err == nil {
+			_ = e.cache.Set(ctx, e.cacheKey(sel), string(payload), e.ttl)
 		}
 	}
 
+	e.applyPolicy(&result, sel)
+	result.Trace = ruleTrace
+	span.SetAttributes(
+		attribute.Bool("rules.blocking", result.Blocking),
+		attribute.Int("rules.violations", len(result.Violations)),
+	)
 	return result, nil
 }
 
+// evaluate runs the base rules and every pack that applies to the selection's
+// market. The result is policy-free so it can be cached.
+func (e *Engine) evaluate(ctx context.Context, sel Selection) (ValidationResult, []RuleTrace) {
+	ev := newEvaluator(ctx, newFacts(sel))
+	ev.run("", e.rules)
+	packs := make([]string, 0)
+	market := configurator.NormalizeMarket(sel.Market)
+	for _, p := range e.packs {
+		if p.appliesTo(market) {
+			ev.run(p.id, p.rules)
+			packs = append(packs, p.id)
+		}
+	}
+	return ValidationResult{
+		ConfigurationID: sel.ConfigurationID,
+		Fingerprint:     sel.Fingerprint(),
+		Violations:      ev.violations,
+		Blocking:        blocks(ev.violations),
+		Packs:           packs,
+	}, ev.trace
+}
+
 func (e *Engine) readFromCache(ctx context.Context, sel Selection) (ValidationResult, bool) {
-	raw, err := e.cache.Get(ctx, sel.cacheKey())
+	raw, err := e.cache.Get(ctx, e.cacheKey(sel))
 	if err != nil {
 		return ValidationResult{}, false
 	}
@@ -96,13 +144,35 @@ func (e *Engine) readFromCache(ctx context.Context, sel Selection) (ValidationRe
 	return res, true
 }
 
+// cacheKey scopes memoized results to the active rule set version so a rule
+// change never serves results computed by an older set.
+func (e *Engine) cacheKey(sel Selection) string {
+	return "rules:" + e.version + ":" + sel.Fingerprint()
+}
+
+// SetAckSecret replaces the per-process key used to sign acknowledgement
+// tokens. Replicas behind one load balancer must share the same secret.
+func (e *Engine) SetAckSecret(secret []byte) {
+	if len(secret) > 0 {
+		e.ackSecret = secret
+	}
+}
+
+// Version reports the version of the rule set the engine evaluates.
+func (e *Engine) Version() string {
+	return e.version
+}
+
 func requireLayoutForOption(optionID, requiredLayout string) constraint {
-	return constraintFunc(func(sel Selection) (Violation, bool) {
-		if hasOption(sel, optionID) && sel.Layout != requiredLayout {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if idx := sel.optionIndex(optionID); idx >= 0 && sel.Layout != requiredLayout {
 			return Violation{
 				Code:     "layout." + optionID,
 				Severity: "error",
 				Message:  fmt.Sprintf("%s requires %s layout", optionID, requiredLayout),
+				Paths:    []string{pointer("options", idx), pointer("layout")},
+				Options:  []string{optionID},
+				Params:   map[string]any{"option": optionID, "requiredLayout": requiredLayout, "layout": sel.Layout},
 			}, true
 		}
 		return Violation{}, false
@@ -114,20 +184,26 @@ func forbidOptionsForLayout(layout string, optionIDs []string) constraint {
 	for _, id := range optionIDs {
 		set[id] = struct{}{}
 	}
-	return constraintFunc(func(sel Selection) (Violation, bool) {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
 		if sel.Layout != layout {
 			return Violation{}, false
 		}
-		for _, opt := range sel.Options {
+		// Every forbidden option is reported in one violation so clients can
+		// highlight each of them, not just the first.
+		var found []Violation
+		for i, opt := range sel.Options {
 			if _, ok := set[opt.ID]; ok {
-				return Violation{
+				found = append(found, Violation{
 					Code:     "layout.blocked",
 					Severity: "error",
 					Message:  fmt.Sprintf("option %s is invalid for %s layout", opt.ID, layout),
-				}, true
+					Paths:    []string{pointer("options", i), pointer("layout")},
+					Options:  []string{opt.ID},
+					Params:   map[string]any{"option": opt.ID, "layout": layout},
+				})
 			}
 		}
-		return Violation{}, false
+		return merged(found)
 	})
 }
 
@@ -136,8 +212,9 @@ func finishCompatibilityRule(optionID string, allowedFinishes []string) constrai
 	for _, finish := range allowedFinishes {
 		allowed[finish] = struct{}{}
 	}
-	return constraintFunc(func(sel Selection) (Violation, bool) {
-		if !hasOption(sel, optionID) {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		idx := sel.optionIndex(optionID)
+		if idx < 0 {
 			return Violation{}, false
 		}
 		if _, ok := allowed[sel.Finish]; !ok {
@@ -145,6 +222,9 @@ func finishCompatibilityRule(optionID string, allowedFinishes []string) constrai
 				Code:     "finish." + optionID,
 				Severity: "error",
 				Message:  fmt.Sprintf("%s only supports %s finishes", optionID, strings.Join(allowedFinishes, ", ")),
+				Paths:    []string{pointer("options", idx), pointer("finish")},
+				Options:  []string{optionID},
+				Params:   map[string]any{"option": optionID, "allowed": allowedFinishes, "requested": sel.Finish},
 			}, true
 		}
 		return Violation{}, false
@@ -152,7 +232,7 @@ func finishCompatibilityRule(optionID string, allowedFinishes []string) constrai
 }
 
 func dimensionBandRule(layout string, min, max int) constraint {
-	return constraintFunc(func(sel Selection) (Violation, bool) {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
 		if sel.Layout != layout {
 			return Violation{}, false
 		}
@@ -165,21 +245,28 @@ func dimensionBandRule(layout string, min, max int) constraint {
 				Code:     "dimension." + layout,
 				Severity: "error",
 				Message:  fmt.Sprintf("layout %s requires length between %dmm and %dmm", layout, min, max),
+				Paths:    []string{pointer("dimensions", "lengthMm"), pointer("layout")},
+				Params:   map[string]any{"layout": layout, "min": min, "max": max, "requested": length},
 			}, true
 		}
 		return Violation{}, false
 	})
 }
 
-func maxApplianceRule(limit int) constraint {
-	applianceOptions := []string{"appliance-panel", "range-upgrade", "cooktop-induction"}
-	return constraintFunc(func(sel Selection) (Violation, bool) {
+func maxApplianceRule(applianceOptions []string, limit int) constraint {
+	appliances := make(map[string]struct{}, len(applianceOptions))
+	for _, id := range applianceOptions {
+		appliances[id] = struct{}{}
+	}
+	return constraintFunc(func(sel *facts) (Violation, bool) {
 		count := 0
-		for _, opt := range sel.Options {
-			for _, candidate := range applianceOptions {
-				if opt.ID == candidate {
-					count += opt.Quantity
-				}
+		paths := make([]string, 0)
+		ids := make([]string, 0)
+		for i, opt := range sel.Options {
+			if _, ok := appliances[opt.ID]; ok {
+				count += opt.Quantity
+				paths = append(paths, pointer("options", i))
+				ids = append(ids, opt.ID)
 			}
 		}
 		if count > limit {
@@ -187,17 +274,11 @@ func maxApplianceRule(limit int) constraint {
 				Code:     "appliance.limit",
 				Severity: "warning",
 				Message:  fmt.Sprintf("appliance upgrades limited to %d (requested %d)", limit, count),
+				Paths:    paths,
+				Options:  ids,
+				Params:   map[string]any{"limit": limit, "requested": count},
 			}, true
 		}
 		return Violation{}, false
 	})
 }
-
-func hasOption(sel Selection, id string) bool {
-	for _, opt := range sel.Options {
-		if opt.ID == id {
-			return true
-		}
-	}
-	return false
-}
@@ -42,6 +42,46 @@ func TestEngineDetectsLayoutRequirements(t *testing.T) {
 	}
 }
 
+func TestCachedResultsFollowTheCallersOptionOrder(t *testing.T) {
+	engine := NewEngine(cache.NewMemoryCache(), time.Minute)
+	sel := Selection{
+		ConfigurationID: "cfg-a",
+		Module:          "galley",
+		Layout:          "linear",
+		Finish:          "matte",
+		Dimensions:      Dimensions{LengthMM: 4200, HeightMM: 900},
+		Options: []SelectionOption{
+			{ID: "drawer-lighting", Quantity: 1},
+			{ID: "island-counter", Quantity: 1},
+		},
+	}
+	if _, err := engine.Validate(context.Background(), sel); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sel.ConfigurationID = "cfg-b"
+	sel.Options = []SelectionOption{sel.Options[1], sel.Options[0]}
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Cached {
+		t.Fatalf("expected the reordered selection to hit the cache")
+	}
+	if res.ConfigurationID != "cfg-b" {
+		t.Fatalf("expected the caller's configuration id, got %q", res.ConfigurationID)
+	}
+	for _, v := range res.Violations {
+		if v.Code == "layout.island-counter" {
+			if v.Paths[0] != "/options/0" {
+				t.Fatalf("expected the path to follow the reordered options, got %v", v.Paths)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the island counter violation, got %+v", res.Violations)
+}
+
 func TestDimensionBandRule(t *testing.T) {
 	engine := NewEngine(cache.NewMemoryCache(), time.Minute)
 	selection := Selection{
@@ -61,3 +101,165 @@ func TestDimensionBandRule(t *testing.T) {
 		t.Fatalf("dimension violation expected for undersized u-shape")
 	}
 }
+
+func TestRuleSetRejectsEmptyDimensionBands(t *testing.T) {
+	for _, band := range []Rule{
+		{ID: "open", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: 3600},
+		{ID: "inverted", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: 9600, MaxMM: 3600},
+		{ID: "negative", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: -1, MaxMM: 3600},
+	} {
+		set := RuleSet{Version: "test", Rules: []Rule{band}}
+		if err := set.Validate(); err == nil {
+			t.Fatalf("band %s should be rejected", band.ID)
+		}
+	}
+}
+
+func TestViolationsCarryFieldPaths(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	selection := Selection{
+		Module:     "galley",
+		Layout:     "u-shape",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 2000},
+		Options: []SelectionOption{
+			{ID: "drawer-lighting", Quantity: 1},
+			{ID: "glass-cabinet", Quantity: 1},
+		},
+	}
+
+	res, err := engine.Validate(context.Background(), selection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byCode := make(map[string]Violation, len(res.Violations))
+	for _, v := range res.Violations {
+		byCode[v.Code] = v
+	}
+
+	finish, ok := byCode["finish.glass-cabinet"]
+	if !ok {
+		t.Fatalf("expected finish violation, got %+v", res.Violations)
+	}
+	if len(finish.Paths) != 2 || finish.Paths[0] != "/options/1" || finish.Paths[1] != "/finish" {
+		t.Fatalf("unexpected finish paths: %v", finish.Paths)
+	}
+	if len(finish.Options) != 1 || finish.Options[0] != "glass-cabinet" {
+		t.Fatalf("unexpected affected options: %v", finish.Options)
+	}
+
+	band, ok := byCode["dimension.u-shape"]
+	if !ok {
+		t.Fatalf("expected dimension violation, got %+v", res.Violations)
+	}
+	if band.Paths[0] != "/dimensions/lengthMm" {
+		t.Fatalf("unexpected dimension path: %v", band.Paths)
+	}
+	if band.Params["min"] != 3600 || band.Params["max"] != 9600 || band.Params["requested"] != 2000 {
+		t.Fatalf("unexpected dimension params: %v", band.Params)
+	}
+}
+
+func TestPointerEscapesTokens(t *testing.T) {
+	if got := pointer("options", 2, "a/b~c"); got != "/options/2/a~1b~0c" {
+		t.Fatalf("unexpected pointer: %s", got)
+	}
+}
+
+func TestCategoryRulesResolveFromCatalog(t *testing.T) {
+	set := RuleSet{Version: "categories", Rules: []Rule{
+		{ID: "one-appliance", Kind: RuleMaxAppliances, Category: "appliance", Limit: 1},
+		{ID: "no-storage-on-linear", Kind: RuleForbidOptions, Layout: "linear", Category: "storage"},
+	}}
+	engine, err := NewEngineWithRules(set, nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := engine.Validate(context.Background(), Selection{
+		Module: "galley",
+		Layout: "linear",
+		Options: []SelectionOption{
+			{ID: "appliance-panel", Quantity: 1},
+			{ID: "cooktop-induction", Quantity: 1},
+			{ID: "drawer-organizer", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Violations) != 2 || res.Violations[0].Code != "appliance.limit" || res.Violations[1].Code != "layout.blocked" {
+		t.Fatalf("expected category rules to fire, got %+v", res.Violations)
+	}
+
+	bad := Rule{ID: "x", Kind: RuleMaxAppliances, Category: "spaceships", Limit: 1}
+	if err := bad.Validate(); err == nil {
+		t.Fatalf("expected unknown category to be rejected")
+	}
+}
+
+func TestForbiddenOptionsAreAllReported(t *testing.T) {
+	engine := NewEngine(cache.NewMemoryCache(), time.Minute)
+	res, err := engine.Validate(context.Background(), Selection{
+		Module: "galley",
+		Layout: "linear",
+		Options: []SelectionOption{
+			{ID: "corner-carousel", Quantity: 1},
+			{ID: "drawer-organizer", Quantity: 1},
+			{ID: "pull-out-pantry", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var blocked *Violation
+	for i := range res.Violations {
+		if res.Violations[i].Code == "layout.blocked" {
+			blocked = &res.Violations[i]
+		}
+	}
+	if blocked == nil {
+		t.Fatalf("expected a layout.blocked violation, got %+v", res.Violations)
+	}
+	if len(blocked.Options) != 2 || blocked.Options[0] != "corner-carousel" || blocked.Options[1] != "pull-out-pantry" {
+		t.Fatalf("expected both forbidden options, got %v", blocked.Options)
+	}
+	want := []string{"/options/0", "/layout", "/options/2"}
+	if len(blocked.Paths) != len(want) {
+		t.Fatalf("expected paths %v, got %v", want, blocked.Paths)
+	}
+	for i := range want {
+		if blocked.Paths[i] != want[i] {
+			t.Fatalf("expected paths %v, got %v", want, blocked.Paths)
+		}
+	}
+	if blocked.Params["count"] != 2 {
+		t.Fatalf("expected a count of 2, got %v", blocked.Params["count"])
+	}
+}
+
+func TestOptionQuantityFollowsCatalog(t *testing.T) {
+	sel := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Options: []SelectionOption{{ID: "backsplash", Quantity: 2}},
+	}
+	if res, err := NewEngine(nil, 0).Validate(context.Background(), sel); err != nil || len(res.Violations) != 0 {
+		t.Fatalf("the built-in rules should not check quantities, got %+v (%v)", res.Violations, err)
+	}
+
+	engine, err := NewEngineWithRules(RuleSet{Version: "quantities", Rules: []Rule{{ID: "option-quantities", Kind: RuleOptionQuantity}}}, nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Code != "option.quantity.max" || !res.Blocking {
+		t.Fatalf("expected a blocking quantity violation, got %+v", res.Violations)
+	}
+	if res.Violations[0].Paths[0] != "/options/0/quantity" {
+		t.Fatalf("unexpected path %v", res.Violations[0].Paths)
+	}
+}
@@ -0,0 +1,214 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+)
+
+// Placement roles used by the ergonomic checks.
+const (
+	RoleSink         = "sink"
+	RoleCooktop      = "cooktop"
+	RoleRefrigerator = "refrigerator"
+	RoleDishwasher   = "dishwasher"
+)
+
+// ErgonomicsPack returns the optional pack guiding users towards layouts that
+// are comfortable to cook in. Thresholds follow the usual kitchen planning
+// guidelines; every rule is advisory so it never blocks an order.
+func ErgonomicsPack() RulePack {
+	return RulePack{
+		ID:      "ergonomics",
+		Markets: []string{AllMarkets},
+		Rules: []Rule{
+			{ID: "ergonomics-work-triangle", Kind: RuleWorkTriangle, Severity: "warning", MinMM: 1200, MaxMM: 2700, Limit: 7900},
+			{ID: "ergonomics-sink-landing", Kind: RuleLandingArea, Severity: "warning", Roles: []string{RoleSink}, MinMM: 600, OtherSideMM: 450},
+			{ID: "ergonomics-cooktop-landing", Kind: RuleLandingArea, Severity: "warning", Roles: []string{RoleCooktop}, MinMM: 380, OtherSideMM: 300},
+			{ID: "ergonomics-refrigerator-landing", Kind: RuleLandingArea, Severity: "warning", Roles: []string{RoleRefrigerator}, MinMM: 380},
+			{ID: "ergonomics-dishwasher-sink", Kind: RuleApplianceDistance, Severity: "info", Roles: []string{RoleDishwasher, RoleSink}, MaxMM: 900},
+		},
+	}
+}
+
+// centre returns the plan position of a placement's midpoint using the wall's
+// origin and heading.
+func centre(r Room, p Placement) (float64, float64, bool) {
+	wall, ok := r.Wall(p.WallID)
+	if !ok {
+		return 0, 0, false
+	}
+	rad := float64(wall.HeadingDeg) * math.Pi / 180
+	along := float64(p.OffsetMM) + float64(p.WidthMM)/2
+	return float64(wall.XMM) + math.Cos(rad)*along, float64(wall.YMM) + math.Sin(rad)*along, true
+}
+
+// placementWithRole returns the first placement with the role and its index,
+// or -1 when none is placed.
+func placementWithRole(r Room, role string) (Placement, int) {
+	for i, p := range r.Placements {
+		if p.Role == role {
+			return p, i
+		}
+	}
+	return Placement{}, -1
+}
+
+func distance(r Room, a, b Placement) (float64, bool) {
+	ax, ay, okA := centre(r, a)
+	bx, by, okB := centre(r, b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return math.Hypot(ax-bx, ay-by), true
+}
+
+// landing measures the uninterrupted worktop run on each side of an
+// appliance. Only base units without a role of their own count as landing.
+func landing(r Room, p Placement) (int, int) {
+	walk := func(edge int, left bool) int {
+		total := 0
+		for {
+			next, ok := adjacentWorktop(r, p.WallID, edge, left)
+			if !ok {
+				return total
+			}
+			total += next.WidthMM
+			if left {
+				edge = next.OffsetMM
+			} else {
+				edge = next.OffsetMM + next.WidthMM
+			}
+		}
+	}
+	return walk(p.OffsetMM, true), walk(p.OffsetMM+p.WidthMM, false)
+}
+
+func adjacentWorktop(r Room, wallID string, edge int, left bool) (Placement, bool) {
+	for _, q := range r.Placements {
+		if q.WallID != wallID || q.Tier != TierBase {
+			continue
+		}
+		if q.Role != "" && q.Role != RoleDishwasher {
+			continue
+		}
+		if (left && q.OffsetMM+q.WidthMM == edge) || (!left && q.OffsetMM == edge) {
+			return q, true
+		}
+	}
+	return Placement{}, false
+}
+
+// workTriangleRule checks each leg between sink, cooktop and refrigerator
+// stays within [minLeg, maxLeg] and the perimeter within maxPerimeter.
+func workTriangleRule(minLeg, maxLeg, maxPerimeter int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil {
+			return Violation{}, false
+		}
+		roles := []string{RoleSink, RoleCooktop, RoleRefrigerator}
+		points := make([]Placement, 0, len(roles))
+		paths := make([]string, 0, len(roles))
+		for _, role := range roles {
+			p, idx := placementWithRole(*sel.Room, role)
+			if idx < 0 {
+				return Violation{}, false
+			}
+			points = append(points, p)
+			paths = append(paths, pointer("room", "placements", idx))
+		}
+
+		perimeter := 0.0
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			leg, ok := distance(*sel.Room, a, b)
+			if !ok {
+				return Violation{}, false
+			}
+			perimeter += leg
+			if int(leg) < minLeg || int(leg) > maxLeg {
+				return Violation{
+					Code:     "ergonomics.triangle.leg",
+					Severity: "warning",
+					Message: fmt.Sprintf("%s to %s is %dmm; work triangle legs should be %dmm-%dmm",
+						a.Role, b.Role, int(leg), minLeg, maxLeg),
+					Paths:  []string{paths[i], paths[(i+1)%len(paths)]},
+					Params: map[string]any{"from": a.Role, "to": b.Role, "min": minLeg, "max": maxLeg, "requested": int(leg)},
+				}, true
+			}
+		}
+		if maxPerimeter > 0 && int(perimeter) > maxPerimeter {
+			return Violation{
+				Code:     "ergonomics.triangle.perimeter",
+				Severity: "warning",
+				Message:  fmt.Sprintf("work triangle perimeter is %dmm; keep it under %dmm", int(perimeter), maxPerimeter),
+				Paths:    paths,
+				Params:   map[string]any{"max": maxPerimeter, "requested": int(perimeter)},
+			}, true
+		}
+		return Violation{}, false
+	})
+}
+
+// landingAreaRule requires worktop of at least primaryMM on one side of the
+// appliance and otherMM on the other.
+func landingAreaRule(role string, primaryMM, otherMM int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil {
+			return Violation{}, false
+		}
+		p, idx := placementWithRole(*sel.Room, role)
+		if idx < 0 {
+			return Violation{}, false
+		}
+		left, right := landing(*sel.Room, p)
+		wide, narrow := maxInt(left, right), minInt(left, right)
+		if wide < primaryMM || narrow < otherMM {
+			return Violation{
+				Code:     "ergonomics.landing." + role,
+				Severity: "warning",
+				Message: fmt.Sprintf("%s has %dmm and %dmm of landing space; recommended %dmm and %dmm",
+					role, wide, narrow, primaryMM, otherMM),
+				Paths:  []string{pointer("room", "placements", idx)},
+				Params: map[string]any{"role": role, "min": primaryMM, "minOther": otherMM, "requested": wide, "requestedOther": narrow},
+			}, true
+		}
+		return Violation{}, false
+	})
+}
+
+// applianceDistanceRule keeps two appliances within maxMM edge to edge.
+func applianceDistanceRule(fromRole, toRole string, maxMM int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil {
+			return Violation{}, false
+		}
+		from, fromIdx := placementWithRole(*sel.Room, fromRole)
+		to, toIdx := placementWithRole(*sel.Room, toRole)
+		if fromIdx < 0 || toIdx < 0 {
+			return Violation{}, false
+		}
+		var gap int
+		if from.WallID == to.WallID {
+			gap = maxInt(from.OffsetMM, to.OffsetMM) - minInt(from.OffsetMM+from.WidthMM, to.OffsetMM+to.WidthMM)
+		} else {
+			centres, ok := distance(*sel.Room, from, to)
+			if !ok {
+				return Violation{}, false
+			}
+			gap = int(centres) - (from.WidthMM+to.WidthMM)/2
+		}
+		if gap < 0 {
+			gap = 0
+		}
+		if gap > maxMM {
+			return Violation{
+				Code:     "ergonomics.distance." + fromRole,
+				Severity: "info",
+				Message:  fmt.Sprintf("%s is %dmm from the %s; keep it within %dmm", fromRole, gap, toRole, maxMM),
+				Paths:    []string{pointer("room", "placements", fromIdx), pointer("room", "placements", toIdx)},
+				Params:   map[string]any{"from": fromRole, "to": toRole, "max": maxMM, "requested": gap},
+			}, true
+		}
+		return Violation{}, false
+	})
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"testing"
+)
+
+func ergonomicsEngine(t *testing.T) *Engine {
+	t.Helper()
+	engine, err := NewEngineWithRules(DefaultRuleSet().WithPack(ErgonomicsPack()), nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return engine
+}
+
+func lShapeRoom(placements []Placement) Room {
+	return Room{
+		CeilingHeightMM: 2500,
+		Walls: []Wall{
+			{ID: "north", LengthMM: 3600},
+			{ID: "east", LengthMM: 3000, XMM: 3600, HeadingDeg: 90},
+		},
+		Placements: placements,
+	}
+}
+
+func TestErgonomicsAcceptsComfortableLayout(t *testing.T) {
+	sel := roomSelection(lShapeRoom([]Placement{
+		{Module: "base", WallID: "north", Tier: TierBase, OffsetMM: 0, WidthMM: 1200},
+		{Module: "base", WallID: "north", Tier: TierBase, OffsetMM: 1200, WidthMM: 800},
+		{Module: "sink", WallID: "north", Tier: TierBase, OffsetMM: 2000, WidthMM: 800, Role: RoleSink},
+		{Module: "dishwasher", WallID: "north", Tier: TierBase, OffsetMM: 2800, WidthMM: 600, Role: RoleDishwasher},
+		{Module: "corner", WallID: "east", Tier: TierBase, OffsetMM: 0, WidthMM: 600},
+		{Module: "hob", WallID: "east", Tier: TierBase, OffsetMM: 600, WidthMM: 600, Role: RoleCooktop},
+		{Module: "base", WallID: "east", Tier: TierBase, OffsetMM: 1200, WidthMM: 800},
+		{Module: "fridge", WallID: "east", Tier: TierTall, OffsetMM: 2000, WidthMM: 600, HeightMM: 2100, Role: RoleRefrigerator},
+	}))
+
+	res, err := ergonomicsEngine(t).Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", res.Violations)
+	}
+}
+
+func TestErgonomicsWarnsWithoutBlocking(t *testing.T) {
+	sel := roomSelection(lShapeRoom([]Placement{
+		{Module: "sink", WallID: "north", Tier: TierBase, OffsetMM: 0, WidthMM: 800, Role: RoleSink},
+		{Module: "hob", WallID: "north", Tier: TierBase, OffsetMM: 800, WidthMM: 600, Role: RoleCooktop},
+		{Module: "fridge", WallID: "east", Tier: TierTall, OffsetMM: 2400, WidthMM: 600, HeightMM: 2100, Role: RoleRefrigerator},
+		{Module: "dishwasher", WallID: "east", Tier: TierBase, OffsetMM: 1800, WidthMM: 600, Role: RoleDishwasher},
+	}))
+
+	res, err := ergonomicsEngine(t).Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, code := range []string{"ergonomics.triangle.leg", "ergonomics.landing.sink", "ergonomics.landing.cooktop", "ergonomics.distance.dishwasher"} {
+		if !hasViolation(res, code) {
+			t.Fatalf("expected %s, got %+v", code, res.Violations)
+		}
+	}
+	if res.Blocking {
+		t.Fatalf("ergonomic guidance must not block the order")
+	}
+}
+
+func TestErgonomicsPackIsOptional(t *testing.T) {
+	sel := roomSelection(lShapeRoom([]Placement{
+		{Module: "sink", WallID: "north", Tier: TierBase, OffsetMM: 0, WidthMM: 800, Role: RoleSink},
+	}))
+	res, err := NewEngine(nil, 0).Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("base rules should not run ergonomic checks, got %+v", res.Violations)
+	}
+}
+
+func TestErgonomicRulesCannotBlock(t *testing.T) {
+	for _, rule := range ErgonomicsPack().Rules {
+		rule.Severity = "error"
+		if err := rule.Validate(); err == nil {
+			t.Fatalf("%s: expected severity error to be rejected", rule.ID)
+		}
+		rule.Severity = ""
+		if err := rule.Validate(); err != nil {
+			t.Fatalf("%s: default severity should be accepted: %v", rule.ID, err)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// maxFixSize bounds how many extra changes a suggested fix may combine.
+	maxFixSize = 2
+	// maxFixes caps the suggestions returned for one explanation.
+	maxFixes = 5
+	// maxFixOptions bounds the options a selection may carry into a fix
+	// search; larger selections are rejected rather than searched.
+	maxFixOptions = 24
+	// maxFixMoves caps the single changes a fix search combines, which keeps
+	// a search at most maxFixMoves choose maxFixSize probes.
+	maxFixMoves = 32
+)
+
+// Explanation tells a client whether a candidate change is allowed, which
+// rules stand in the way and which other changes would unblock it.
+type Explanation struct {
+	Change  Change   `json:"change"`
+	Allowed bool     `json:"allowed"`
+	Reasons []Reason `json:"reasons"`
+	Fixes   []Fix    `json:"fixes"`
+}
+
+// Reason is one violation the candidate change introduces, attributed to the
+// rule and pack that raised it. Reasons follow evaluation order: base rules
+// first, then market packs.
+type Reason struct {
+	Rule      string    `json:"rule"`
+	Kind      RuleKind  `json:"kind"`
+	Pack      string    `json:"pack,omitempty"`
+	Blocking  bool      `json:"blocking"`
+	Violation Violation `json:"violation"`
+}
+
+// Fix is a set of additional changes that makes the candidate change allowed.
+type Fix struct {
+	Changes []Change `json:"changes"`
+}
+
+// Explain evaluates the selection with and without the change. Only
+// violations the change introduces count against it, so a selection that is
+// already blocked for unrelated reasons can still accept the change. Fixes
+// are searched breadth-first over layout and finish switches and removal of
+// other options, returning every fix of the smallest size found. The search
+// stops with the context's error once it is cancelled.
+func (e *Engine) Explain(ctx context.Context, sel Selection, change Change) (Explanation, error) {
+	if err := change.Validate(); err != nil {
+		return Explanation{}, err
+	}
+	if err := validateFixSearch(sel); err != nil {
+		return Explanation{}, err
+	}
+	candidate := change.Apply(sel)
+	if err := validateFixSearch(candidate); err != nil {
+		return Explanation{}, err
+	}
+
+	baseline, err := e.probe(ctx, sel)
+	if err != nil {
+		return Explanation{}, err
+	}
+	after, err := e.probe(ctx, candidate)
+	if err != nil {
+		return Explanation{}, err
+	}
+	introduced := introducedViolations(baseline, after)
+	out := Explanation{
+		Change:  change,
+		Allowed: !blocks(introduced),
+		Reasons: make([]Reason, 0, len(introduced)),
+		Fixes:   make([]Fix, 0),
+	}
+	for _, v := range introduced {
+		kind, pack := e.describe(v.Rule)
+		out.Reasons = append(out.Reasons, Reason{
+			Rule:      v.Rule,
+			Kind:      kind,
+			Pack:      pack,
+			Blocking:  blocks([]Violation{v}),
+			Violation: v,
+		})
+	}
+	if out.Allowed {
+		return out, nil
+	}
+
+	out.Fixes, err = e.searchFixes(ctx, candidate, e.fixMoves(candidate, change), func(after []Violation) bool {
+		return !blocks(introducedViolations(baseline, after))
+	})
+	if err != nil {
+		return Explanation{}, err
+	}
+	return out, nil
+}
+
+// Repairs returns the smallest sets of changes that make a blocking
+// selection pass under its channel policy, searched like Explain's fixes. A
+// selection that does not block needs no repair.
+func (e *Engine) Repairs(ctx context.Context, sel Selection) ([]Fix, error) {
+	if err := validateFixSearch(sel); err != nil {
+		return nil, err
+	}
+	violations, err := e.probe(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+	if !blocks(violations) {
+		return make([]Fix, 0), nil
+	}
+	return e.searchFixes(ctx, sel, e.fixMoves(sel, Change{}), func(after []Violation) bool {
+		return !blocks(after)
+	})
+}
+
+// validateFixSearch checks a selection and bounds its size, since the search
+// probes combinations of its options.
+func validateFixSearch(sel Selection) error {
+	if err := validateSelection(sel); err != nil {
+		return err
+	}
+	if len(sel.Options) > maxFixOptions {
+		return fmt.Errorf("fix search supports at most %d options", maxFixOptions)
+	}
+	return nil
+}
+
+// searchFixes tries combinations of moves breadth-first and returns every
+// accepted combination of the smallest size found, at most maxFixes. It
+// gives up with the context's error once the context is done.
+func (e *Engine) searchFixes(ctx context.Context, sel Selection, moves []Change, accept func([]Violation) bool) ([]Fix, error) {
+	fixes := make([]Fix, 0)
+	var err error
+	for size := 1; size <= maxFixSize && len(fixes) == 0 && err == nil; size++ {
+		combine(moves, size, func(fix []Change) bool {
+			probe := sel
+			for _, c := range fix {
+				probe = c.Apply(probe)
+			}
+			var after []Violation
+			if after, err = e.probe(ctx, probe); err != nil {
+				return false
+			}
+			if !accept(after) {
+				return true
+			}
+			fixes = append(fixes, Fix{Changes: append([]Change(nil), fix...)})
+			return len(fixes) < maxFixes
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fixes, nil
+}
+
+// probe evaluates a selection with the caller's channel policy applied,
+// bypassing the cache so exploratory selections never evict real ones.
+func (e *Engine) probe(ctx context.Context, sel Selection) ([]Violation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	res, _ := e.evaluate(ctx, sel)
+	e.applyPolicy(&res, sel)
+	return res.Violations, nil
+}
+
+// fixMoves lists the single changes a fix may use: switching to another
+// layout or finish from the domain, or dropping another selected option.
+// Moves that touch what the candidate change edits are excluded, and the list
+// is capped at maxFixMoves.
+func (e *Engine) fixMoves(sel Selection, change Change) []Change {
+	domain := DefaultDomain()
+	moves := make([]Change, 0, len(domain.Layouts)+len(domain.Finishes)+len(sel.Options))
+	skip := change.target()
+	for _, layout := range domain.Layouts {
+		if m := (Change{Op: OpSetLayout, Layout: layout}); layout != sel.Layout && m.target() != skip {
+			moves = append(moves, m)
+		}
+	}
+	for _, finish := range domain.Finishes {
+		if m := (Change{Op: OpSetFinish, Finish: finish}); finish != sel.Finish && m.target() != skip {
+			moves = append(moves, m)
+		}
+	}
+	for _, opt := range sel.Options {
+		if m := (Change{Op: OpRemoveOption, Option: opt.ID}); m.target() != skip {
+			moves = append(moves, m)
+		}
+	}
+	if len(moves) > maxFixMoves {
+		moves = moves[:maxFixMoves]
+	}
+	return moves
+}
+
+// combine calls visit with every size-k combination of moves that edits k
+// distinct targets, stopping early when visit returns false.
+func combine(moves []Change, k int, visit func([]Change) bool) {
+	picked := make([]Change, 0, k)
+	used := make(map[string]bool, k)
+	var walk func(start int) bool
+	walk = func(start int) bool {
+		if len(picked) == k {
+			return visit(picked)
+		}
+		for i := start; i < len(moves); i++ {
+			t := moves[i].target()
+			if used[t] {
+				continue
+			}
+			used[t] = true
+			picked = append(picked, moves[i])
+			more := walk(i + 1)
+			picked = picked[:len(picked)-1]
+			delete(used, t)
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	walk(0)
+}
+
+// introducedViolations returns the violations in after that before does not
+// already carry. A violation is already there when the same rule raised the
+// same code for the same offenders: its options, or its paths when it names
+// no options. A rule that already fires still introduces a violation when
+// the change adds an offender to it.
+func introducedViolations(before, after []Violation) []Violation {
+	seen := make(map[string]map[string]struct{}, len(before))
+	for _, v := range before {
+		key := v.Rule + "\x00" + v.Code
+		if seen[key] == nil {
+			seen[key] = make(map[string]struct{})
+		}
+		for _, o := range offenders(v) {
+			seen[key][o] = struct{}{}
+		}
+	}
+	out := make([]Violation, 0)
+	for _, v := range after {
+		known, ok := seen[v.Rule+"\x00"+v.Code]
+		if !ok {
+			out = append(out, v)
+			continue
+		}
+		for _, o := range offenders(v) {
+			if _, dup := known[o]; !dup {
+				out = append(out, v)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// offenders identifies what a violation is about. Options are stable across
+// changes; paths are only used without options, since removing an option
+// renumbers the option paths after it.
+func offenders(v Violation) []string {
+	if len(v.Options) > 0 {
+		return v.Options
+	}
+	return v.Paths
+}
+
+// describe returns the kind and pack of a compiled rule.
+func (e *Engine) describe(id string) (RuleKind, string) {
+	for _, r := range e.rules.rules {
+		if r.id == id {
+			return r.kind, ""
+		}
+	}
+	for _, p := range e.packs {
+		for _, r := range p.rules.rules {
+			if r.id == id {
+				return r.kind, p.id
+			}
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExplainBlockedOptionSuggestsLayout(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{Module: "galley", Layout: "linear", Finish: "matte"}
+
+	exp, err := engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "island-counter"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp.Allowed {
+		t.Fatalf("island counter on a linear layout should not be allowed")
+	}
+	if len(exp.Reasons) != 1 || exp.Reasons[0].Rule != "island-counter-requires-island" || exp.Reasons[0].Kind != RuleRequireLayout || !exp.Reasons[0].Blocking {
+		t.Fatalf("unexpected reasons %+v", exp.Reasons)
+	}
+	if len(exp.Fixes) != 1 || len(exp.Fixes[0].Changes) != 1 {
+		t.Fatalf("expected a single one-step fix, got %+v", exp.Fixes)
+	}
+	if fix := exp.Fixes[0].Changes[0]; fix.Op != OpSetLayout || fix.Layout != "island" {
+		t.Fatalf("expected switching to the island layout, got %+v", fix)
+	}
+}
+
+func TestExplainIgnoresExistingViolations(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{
+		Module:     "galley",
+		Layout:     "u-shape",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 12000},
+	}
+	exp, err := engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "drawer-organizer"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exp.Allowed || len(exp.Reasons) != 0 || len(exp.Fixes) != 0 {
+		t.Fatalf("an unrelated existing violation should not block the change: %+v", exp)
+	}
+}
+
+func TestExplainReportsNewOffendersOfARuleThatAlreadyFires(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Finish:  "matte",
+		Options: []SelectionOption{{ID: "corner-carousel", Quantity: 1}},
+	}
+	exp, err := engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "pull-out-pantry"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp.Allowed {
+		t.Fatalf("a pantry pull-out on a linear layout should not be allowed")
+	}
+	if len(exp.Reasons) != 1 || exp.Reasons[0].Rule != "linear-forbids-corner-units" || !exp.Reasons[0].Blocking {
+		t.Fatalf("unexpected reasons %+v", exp.Reasons)
+	}
+	for _, fix := range exp.Fixes {
+		if len(fix.Changes) == 1 && fix.Changes[0].Op == OpRemoveOption {
+			t.Fatalf("removing the existing offender does not make the pull-out allowed: %+v", exp.Fixes)
+		}
+	}
+
+	// Re-adding an option that already offends introduces nothing new.
+	exp, err = engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "corner-carousel", Quantity: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exp.Allowed || len(exp.Reasons) != 0 {
+		t.Fatalf("an existing offender should not block the change: %+v", exp)
+	}
+}
+
+func TestExplainFixesLeaveCandidateUntouched(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	// The glass cabinet only fits some finishes; fixes must never undo the
+	// candidate change itself.
+	sel := Selection{
+		Module:     "galley",
+		Layout:     "linear",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 5000},
+		Options:    []SelectionOption{{ID: "island-counter", Quantity: 1}},
+	}
+	exp, err := engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "glass-cabinet"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp.Allowed || len(exp.Fixes) == 0 {
+		t.Fatalf("expected fixes for the glass cabinet, got %+v", exp)
+	}
+	for _, fix := range exp.Fixes {
+		if len(fix.Changes) != 1 || fix.Changes[0].Op != OpSetFinish {
+			t.Fatalf("expected finish-only fixes, got %+v", fix)
+		}
+	}
+}
+
+func TestExplainRejectsMalformedChange(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	if _, err := engine.Explain(context.Background(), Selection{Module: "galley", Layout: "linear"}, Change{Op: "paint"}); err == nil {
+		t.Fatalf("expected an unknown op to be rejected")
+	}
+}
+
+func TestFixSearchStopsWhenTheContextIsDone(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{Module: "galley", Layout: "linear", Finish: "matte"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := engine.Explain(ctx, sel, Change{Op: OpAddOption, Option: "island-counter"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the explanation to stop with context.Canceled, got %v", err)
+	}
+	sel.Options = []SelectionOption{{ID: "island-counter", Quantity: 1}}
+	if _, err := engine.Repairs(ctx, sel); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the repair search to stop with context.Canceled, got %v", err)
+	}
+}
+
+func TestFixSearchBoundsTheSelection(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{Module: "galley", Layout: "linear", Finish: "matte"}
+	for i := 0; i <= maxFixOptions; i++ {
+		sel.Options = append(sel.Options, SelectionOption{ID: fmt.Sprintf("opt-%d", i), Quantity: 1})
+	}
+	if _, err := engine.Repairs(context.Background(), sel); err == nil {
+		t.Fatalf("expected a selection with %d options to be rejected", len(sel.Options))
+	}
+	if _, err := engine.Explain(context.Background(), sel, Change{Op: OpAddOption, Option: "island-counter"}); err == nil {
+		t.Fatalf("expected an oversized explanation to be rejected")
+	}
+
+	sel.Options = sel.Options[:maxFixOptions]
+	if moves := engine.fixMoves(sel, Change{}); len(moves) > maxFixMoves {
+		t.Fatalf("expected at most %d moves, got %d", maxFixMoves, len(moves))
+	}
+}
+
+func TestRepairsUnblockSelection(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Finish:  "matte",
+		Options: []SelectionOption{{ID: "island-counter", Quantity: 1}},
+	}
+	fixes, err := engine.Repairs(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fixes) != 2 {
+		t.Fatalf("expected switching layout or dropping the counter, got %+v", fixes)
+	}
+	for _, fix := range fixes {
+		repaired := sel
+		for _, c := range fix.Changes {
+			repaired = c.Apply(repaired)
+		}
+		res, err := engine.Validate(context.Background(), repaired)
+		if err != nil || res.Blocking {
+			t.Fatalf("fix %+v should unblock the selection (err %v)", fix, err)
+		}
+	}
+
+	sel.Layout = "island"
+	if fixes, err := engine.Repairs(context.Background(), sel); err != nil || len(fixes) != 0 {
+		t.Fatalf("a valid selection needs no repair, got %+v %v", fixes, err)
+	}
+}
@@ -0,0 +1,317 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+// Snapshot is the policy-free, per-rule outcome of validating a selection.
+// It is the state an incremental session carries between updates: results
+// are keyed by pack and rule ID so an update can replace exactly the rules it
+// re-evaluates.
+type Snapshot struct {
+	Version   string               `json:"version"`
+	Selection Selection            `json:"selection"`
+	Results   map[string]Violation `json:"results"`
+}
+
+// Delta reports how the violations a channel sees changed after an update.
+// Updated violations keep their rule and code but changed paths, params or
+// severity. Evaluated counts the rules that actually re-ran; Rebuilt is set
+// when the rule set changed since the snapshot and every rule ran again.
+type Delta struct {
+	Added     []Violation `json:"added"`
+	Updated   []Violation `json:"updated"`
+	Removed   []Violation `json:"removed"`
+	Blocking  bool        `json:"blocking"`
+	Evaluated int         `json:"evaluated"`
+	Rebuilt   bool        `json:"rebuilt,omitempty"`
+}
+
+func resultKey(pack, rule string) string {
+	return pack + "/" + rule
+}
+
+// Snapshot evaluates every candidate rule for the selection and keeps the
+// outcome per rule. It never reads or writes the validation cache.
+func (e *Engine) Snapshot(ctx context.Context, sel Selection) (Snapshot, error) {
+	if err := validateSelection(sel); err != nil {
+		return Snapshot{}, err
+	}
+	f := newFacts(sel)
+	snap := Snapshot{Version: e.version, Selection: sel, Results: make(map[string]Violation)}
+	e.eachIndex(sel.Market, func(pack string, idx ruleIndex) {
+		for _, i := range idx.candidates(f) {
+			r := idx.rules[i]
+			if v, ok := r.c.evaluate(f); ok {
+				v.Rule = r.id
+				snap.Results[resultKey(pack, r.id)] = v
+			}
+		}
+	})
+	return snap, nil
+}
+
+// Update applies changes to the snapshot's selection and re-evaluates only
+// the rules that read something the changes touched: the options whose
+// quantity or position moved, the layout or the finish. Everything else is
+// carried over from the snapshot. A snapshot taken under another rule set
+// version is rebuilt from scratch.
+func (e *Engine) Update(ctx context.Context, snap Snapshot, changes []Change) (Snapshot, Delta, error) {
+	sel := snap.Selection
+	for _, c := range changes {
+		if err := c.Validate(); err != nil {
+			return Snapshot{}, Delta{}, err
+		}
+		sel = c.Apply(sel)
+	}
+	if err := validateSelection(sel); err != nil {
+		return Snapshot{}, Delta{}, err
+	}
+
+	_, span := tracer.Start(ctx, "rules.update", trace.WithAttributes(
+		attribute.String("rules.version", e.version),
+		attribute.Int("rules.changes", len(changes)),
+	))
+	defer span.End()
+
+	if snap.Version != e.version {
+		next, err := e.Snapshot(ctx, sel)
+		if err != nil {
+			return Snapshot{}, Delta{}, err
+		}
+		delta := e.diff(snap, next)
+		delta.Rebuilt = true
+		span.SetAttributes(attribute.Bool("rules.rebuilt", true))
+		return next, delta, nil
+	}
+
+	before, after := newFacts(snap.Selection), newFacts(sel)
+	touched := touchedFacts(before, after)
+	next := Snapshot{Version: e.version, Selection: sel, Results: make(map[string]Violation, len(snap.Results))}
+	for k, v := range snap.Results {
+		next.Results[k] = v
+	}
+	evaluated := 0
+	e.eachIndex(sel.Market, func(pack string, idx ruleIndex) {
+		for _, i := range mergeSorted(idx.candidates(before), idx.candidates(after)) {
+			r := idx.rules[i]
+			if !touched.affects(r) {
+				continue
+			}
+			evaluated++
+			key := resultKey(pack, r.id)
+			if v, ok := r.c.evaluate(after); ok {
+				v.Rule = r.id
+				next.Results[key] = v
+			} else {
+				delete(next.Results, key)
+			}
+		}
+	})
+
+	delta := e.diff(snap, next)
+	delta.Evaluated = evaluated
+	span.SetAttributes(
+		attribute.Int("rules.evaluated", evaluated),
+		attribute.Bool("rules.blocking", delta.Blocking),
+	)
+	return next, delta, nil
+}
+
+// Result renders the snapshot as a full validation result in evaluation
+// order with the selection's channel policy applied.
+func (e *Engine) Result(snap Snapshot) ValidationResult {
+	res := ValidationResult{
+		ConfigurationID: snap.Selection.ConfigurationID,
+		Fingerprint:     snap.Selection.Fingerprint(),
+		Violations:      make([]Violation, 0, len(snap.Results)),
+		Packs:           make([]string, 0),
+	}
+	for _, key := range e.orderedKeys(snap) {
+		res.Violations = append(res.Violations, snap.Results[key])
+	}
+	e.eachIndex(snap.Selection.Market, func(pack string, _ ruleIndex) {
+		if pack != "" {
+			res.Packs = append(res.Packs, pack)
+		}
+	})
+	e.applyPolicy(&res, snap.Selection)
+	return res
+}
+
+// eachIndex visits the base rules and then every pack that applies to the
+// market, in evaluation order.
+func (e *Engine) eachIndex(market string, visit func(pack string, idx ruleIndex)) {
+	visit("", e.rules)
+	market = configurator.NormalizeMarket(market)
+	for _, p := range e.packs {
+		if p.appliesTo(market) {
+			visit(p.id, p.rules)
+		}
+	}
+}
+
+// orderedKeys lists the snapshot's result keys in evaluation order. Keys the
+// engine no longer knows, left by an older rule set, sort last.
+func (e *Engine) orderedKeys(snap Snapshot) []string {
+	keys := make([]string, 0, len(snap.Results))
+	known := make(map[string]struct{}, len(snap.Results))
+	e.eachIndex(snap.Selection.Market, func(pack string, idx ruleIndex) {
+		for _, r := range idx.rules {
+			key := resultKey(pack, r.id)
+			if _, ok := snap.Results[key]; ok {
+				keys = append(keys, key)
+				known[key] = struct{}{}
+			}
+		}
+	})
+	stale := make([]string, 0)
+	for key := range snap.Results {
+		if _, ok := known[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(stale)
+	return append(keys, stale...)
+}
+
+// diff compares what the channel sees before and after an update. Ack tokens
+// are bound to the exact selection, so they are ignored when deciding whether
+// a violation changed.
+func (e *Engine) diff(before, after Snapshot) Delta {
+	old, oldKeys := e.view(before)
+	cur, curKeys := e.view(after)
+	delta := Delta{
+		Added:   make([]Violation, 0),
+		Updated: make([]Violation, 0),
+		Removed: make([]Violation, 0),
+	}
+	visible := make([]Violation, 0, len(cur))
+	for _, key := range curKeys {
+		v := cur[key]
+		visible = append(visible, v)
+		prev, ok := old[key]
+		switch {
+		case !ok:
+			delta.Added = append(delta.Added, v)
+		case !sameViolation(prev, v):
+			delta.Updated = append(delta.Updated, v)
+		}
+	}
+	for _, key := range oldKeys {
+		if _, ok := cur[key]; !ok {
+			delta.Removed = append(delta.Removed, old[key])
+		}
+	}
+	delta.Blocking = blocks(visible)
+	return delta
+}
+
+// view applies the snapshot selection's channel policy to every result.
+func (e *Engine) view(snap Snapshot) (map[string]Violation, []string) {
+	policy := e.policyView(snap.Selection)
+	out := make(map[string]Violation, len(snap.Results))
+	keys := make([]string, 0, len(snap.Results))
+	for _, key := range e.orderedKeys(snap) {
+		if v, ok := policy.apply(snap.Results[key]); ok {
+			out[key] = v
+			keys = append(keys, key)
+		}
+	}
+	return out, keys
+}
+
+// sameViolation compares violations by their JSON form, which also equates
+// params that went through a JSON round trip (ints decode as float64).
+func sameViolation(a, b Violation) bool {
+	a.AckToken, b.AckToken = "", ""
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errors.Join(errA, errB) == nil && bytes.Equal(ja, jb)
+}
+
+// touched records which selection facts differ between two selections.
+type touched struct {
+	options map[string]struct{}
+	layout  bool
+	finish  bool
+}
+
+// touchedFacts compares selections field by field. An option counts as
+// touched when it was added, removed, changed quantity or moved position,
+// since violations point at options by index.
+func touchedFacts(before, after *facts) touched {
+	t := touched{
+		options: make(map[string]struct{}),
+		layout:  before.Layout != after.Layout,
+		finish:  before.Finish != after.Finish,
+	}
+	for _, pair := range [][2]*facts{{before, after}, {after, before}} {
+		from, to := pair[0], pair[1]
+		for id, i := range from.optionAt {
+			j := to.optionIndex(id)
+			if j != i || from.Options[i] != to.Options[j] {
+				t.options[id] = struct{}{}
+			}
+		}
+	}
+	return t
+}
+
+// affects reports whether a rule can change outcome given the touched facts.
+// Option-keyed rules only depend on their own options; other rules reading
+// the options list re-run on any option change.
+func (t touched) affects(r compiledRule) bool {
+	for _, input := range r.inputs {
+		switch input {
+		case "/layout":
+			if t.layout {
+				return true
+			}
+		case "/finish":
+			if t.finish {
+				return true
+			}
+		case "/options":
+			if len(r.triggers.options) == 0 && len(t.options) > 0 {
+				return true
+			}
+		}
+	}
+	for _, id := range r.triggers.options {
+		if _, ok := t.options[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeSorted unions two ascending position lists.
+func mergeSorted(a, b []int) []int {
+	out := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			out = append(out, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
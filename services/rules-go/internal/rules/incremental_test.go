@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestUpdateMatchesFullValidation(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	ctx := context.Background()
+	snap, err := engine.Snapshot(ctx, Selection{
+		Module:     "galley",
+		Layout:     "linear",
+		Finish:     "matte",
+		Market:     "uk",
+		Channel:    "retail-web",
+		Dimensions: Dimensions{LengthMM: 3000},
+		Options:    []SelectionOption{{ID: "appliance-panel", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	steps := [][]Change{
+		{{Op: OpAddOption, Option: "island-counter"}},
+		{{Op: OpAddOption, Option: "range-upgrade", Quantity: 2}},
+		{{Op: OpSetLayout, Layout: "island"}},
+		{{Op: OpAddOption, Option: "glass-cabinet"}},
+		{{Op: OpRemoveOption, Option: "appliance-panel"}},
+		{{Op: OpSetFinish, Finish: "gloss"}, {Op: OpAddOption, Option: "cooktop-induction", Quantity: 3}},
+		{{Op: OpRemoveOption, Option: "island-counter"}, {Op: OpSetLayout, Layout: "l-shape"}},
+	}
+	for n, changes := range steps {
+		// Persisted sessions go through JSON; updates must not depend on Go types.
+		raw, _ := json.Marshal(snap)
+		var stored Snapshot
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			t.Fatalf("step %d: decode snapshot: %v", n, err)
+		}
+
+		next, delta, err := engine.Update(ctx, stored, changes)
+		if err != nil {
+			t.Fatalf("step %d: update: %v", n, err)
+		}
+		full, err := engine.Validate(ctx, next.Selection)
+		if err != nil {
+			t.Fatalf("step %d: validate: %v", n, err)
+		}
+		got := engine.Result(next)
+		if !sameViolations(got.Violations, full.Violations) || got.Blocking != full.Blocking || delta.Blocking != full.Blocking {
+			t.Fatalf("step %d: incremental result diverged\n got %+v\nwant %+v", n, got.Violations, full.Violations)
+		}
+		if delta.Evaluated >= len(engine.rules.rules) {
+			t.Fatalf("step %d: expected a partial re-evaluation, ran %d rules", n, delta.Evaluated)
+		}
+		snap = next
+	}
+}
+
+func TestUpdateReportsOnlyChangedViolations(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	ctx := context.Background()
+	snap, err := engine.Snapshot(ctx, Selection{
+		Module:     "galley",
+		Layout:     "linear",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 3000},
+		Options:    []SelectionOption{{ID: "island-counter", Quantity: 1}, {ID: "glass-cabinet", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// Removing the first option fixes its rule and shifts the glass cabinet's
+	// path, so that violation is reported as updated rather than unchanged.
+	_, delta, err := engine.Update(ctx, snap, []Change{{Op: OpRemoveOption, Option: "island-counter"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(delta.Added) != 0 || len(delta.Removed) != 1 || delta.Removed[0].Rule != "island-counter-requires-island" {
+		t.Fatalf("unexpected delta %+v", delta)
+	}
+	if len(delta.Updated) != 1 || delta.Updated[0].Paths[0] != "/options/0" {
+		t.Fatalf("expected the glass cabinet path to move, got %+v", delta.Updated)
+	}
+
+	_, delta, err = engine.Update(ctx, snap, []Change{{Op: OpAddOption, Option: "drawer-organizer"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(delta.Added)+len(delta.Updated)+len(delta.Removed) != 0 || !delta.Blocking {
+		t.Fatalf("an unrelated option should not change violations, got %+v", delta)
+	}
+}
+
+func TestUpdateRebuildsAcrossRuleSetVersions(t *testing.T) {
+	ctx := context.Background()
+	snap, err := NewEngine(nil, 0).Snapshot(ctx, Selection{Module: "galley", Layout: "linear", Finish: "matte"})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	set := DefaultRuleSet()
+	set.Version = "next"
+	engine, err := NewEngineWithRules(set, nil, 0)
+	if err != nil {
+		t.Fatalf("engine: %v", err)
+	}
+	next, delta, err := engine.Update(ctx, snap, []Change{{Op: OpAddOption, Option: "island-counter"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !delta.Rebuilt || next.Version != "next" || len(delta.Added) != 1 {
+		t.Fatalf("expected a rebuilt snapshot, got %+v", delta)
+	}
+}
+
+func sameViolations(a, b []Violation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameViolation(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package rules
+
+import "sort"
+
+// facts is a selection preprocessed once per validation so rules answer
+// membership questions with a map lookup instead of rescanning the options.
+type facts struct {
+	Selection
+	optionAt map[string]int
+}
+
+func newFacts(sel Selection) *facts {
+	optionAt := make(map[string]int, len(sel.Options))
+	for i, opt := range sel.Options {
+		if _, seen := optionAt[opt.ID]; !seen {
+			optionAt[opt.ID] = i
+		}
+	}
+	return &facts{Selection: sel, optionAt: optionAt}
+}
+
+// optionIndex returns the position of the first option with the given ID, or
+// -1 when it is not selected.
+func (f *facts) optionIndex(id string) int {
+	if i, ok := f.optionAt[id]; ok {
+		return i
+	}
+	return -1
+}
+
+// ruleIndex buckets compiled rules by what can trigger them. A rule lands in
+// exactly one kind of bucket: keyed by option, keyed by layout, room-only, or
+// always run. Buckets hold positions into rules so candidates can be replayed
+// in declaration order.
+type ruleIndex struct {
+	rules    []compiledRule
+	always   []int
+	room     []int
+	byLayout map[string][]int
+	byOption map[string][]int
+}
+
+func newRuleIndex(rules []compiledRule) ruleIndex {
+	idx := ruleIndex{
+		rules:    rules,
+		byLayout: make(map[string][]int),
+		byOption: make(map[string][]int),
+	}
+	for i, r := range rules {
+		t := r.triggers
+		switch {
+		case len(t.options) > 0:
+			for _, id := range t.options {
+				idx.byOption[id] = append(idx.byOption[id], i)
+			}
+		case t.layout != "":
+			idx.byLayout[t.layout] = append(idx.byLayout[t.layout], i)
+		case t.room:
+			idx.room = append(idx.room, i)
+		default:
+			idx.always = append(idx.always, i)
+		}
+	}
+	return idx
+}
+
+// candidates returns, in declaration order, the positions of every rule that
+// could be violated by the selection. The cost scales with the selection and
+// the matching buckets, not with the size of the rule set.
+func (idx ruleIndex) candidates(sel *facts) []int {
+	out := make([]int, 0, len(idx.always)+len(idx.room))
+	out = append(out, idx.always...)
+	if sel.Room != nil {
+		out = append(out, idx.room...)
+	}
+	out = append(out, idx.byLayout[sel.Layout]...)
+	for id := range sel.optionAt {
+		out = append(out, idx.byOption[id]...)
+	}
+	sort.Ints(out)
+
+	// A rule listing several selected options appears once per option.
+	n := 0
+	for i, pos := range out {
+		if i > 0 && pos == out[n-1] {
+			continue
+		}
+		out[n] = pos
+		n++
+	}
+	return out[:n]
+}
+
+// ruleTriggers describes the selection facts a rule needs before it can fire.
+type ruleTriggers struct {
+	options []string
+	layout  string
+	room    bool
+}
+
+// triggers reports what must be present in a selection for the rule to fire.
+// Finish rules are keyed by their option: they fire on any finish outside the
+// allowed list, so the option is the only selective fact.
+func (r Rule) triggers() ruleTriggers {
+	switch r.Kind {
+	case RuleRequireLayout, RuleForbidOptions, RuleFinishOnly:
+		return ruleTriggers{options: r.Options}
+	case RuleMaxAppliances:
+		if r.Limit >= 0 {
+			return ruleTriggers{options: r.Options}
+		}
+	case RuleOptionQuantity:
+		return ruleTriggers{options: optionCatalog.IDs()}
+	case RuleDimensionBand:
+		return ruleTriggers{layout: r.Layout}
+	case RuleWallFit, RuleOpenings, RuleCeilingFit, RuleWalkway,
+		RuleWorkTriangle, RuleLandingArea, RuleApplianceDistance:
+		return ruleTriggers{room: true}
+	}
+	return ruleTriggers{}
+}
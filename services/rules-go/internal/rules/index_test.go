@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// syntheticRuleSet builds n rules spread over option, layout and room kinds,
+// roughly the mix a large catalog produces.
+func syntheticRuleSet(n int) RuleSet {
+	layouts := []string{"linear", "l-shape", "u-shape", "island", "galley"}
+	rules := make([]Rule, 0, n)
+	for i := 0; len(rules) < n; i++ {
+		layout := layouts[i%len(layouts)]
+		option := fmt.Sprintf("opt-%d", i)
+		switch i % 4 {
+		case 0:
+			rules = append(rules, Rule{ID: fmt.Sprintf("require-%d", i), Kind: RuleRequireLayout, Layout: layout, Options: []string{option}})
+		case 1:
+			rules = append(rules, Rule{ID: fmt.Sprintf("forbid-%d", i), Kind: RuleForbidOptions, Layout: layout, Options: []string{option, fmt.Sprintf("opt-%d", i+1)}})
+		case 2:
+			rules = append(rules, Rule{ID: fmt.Sprintf("finish-%d", i), Kind: RuleFinishOnly, Options: []string{option}, Finishes: []string{"gloss"}})
+		case 3:
+			rules = append(rules, Rule{ID: fmt.Sprintf("limit-%d", i), Kind: RuleMaxAppliances, Options: []string{option, fmt.Sprintf("opt-%d", i-1)}, Limit: 1})
+		}
+	}
+	rules = append(rules, Rule{ID: "band", Kind: RuleDimensionBand, Layout: "linear", MinMM: 1800, MaxMM: 6000})
+	rules = append(rules, Rule{ID: "walls", Kind: RuleWallFit})
+	return RuleSet{Version: fmt.Sprintf("synthetic-%d", n), Rules: rules}
+}
+
+func syntheticSelection() Selection {
+	return Selection{
+		Module:     "galley",
+		Layout:     "linear",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 7000},
+		Options: []SelectionOption{
+			{ID: "opt-0", Quantity: 1},
+			{ID: "opt-2", Quantity: 1},
+			{ID: "opt-3", Quantity: 2},
+			{ID: "opt-6", Quantity: 1},
+		},
+	}
+}
+
+func TestIndexMatchesFullScan(t *testing.T) {
+	set := syntheticRuleSet(400)
+	engine, err := NewEngineWithRules(set, nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sel := syntheticSelection()
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := make([]Violation, 0)
+	f := newFacts(sel)
+	for _, r := range compileRules(set.Rules) {
+		if v, violated := r.c.evaluate(f); violated {
+			v.Rule = r.id
+			want = append(want, v)
+		}
+	}
+	if len(want) == 0 {
+		t.Fatalf("synthetic selection should violate some rules")
+	}
+	if !reflect.DeepEqual(res.Violations, want) {
+		t.Fatalf("indexed evaluation diverged from a full scan:\n got %+v\nwant %+v", res.Violations, want)
+	}
+}
+
+func TestIndexDeduplicatesMultiOptionRules(t *testing.T) {
+	idx := newRuleIndex(compileRules([]Rule{
+		{ID: "limit", Kind: RuleMaxAppliances, Options: []string{"a", "b"}, Limit: 1},
+	}))
+	got := idx.candidates(newFacts(Selection{Options: []SelectionOption{{ID: "a", Quantity: 1}, {ID: "b", Quantity: 1}}}))
+	if len(got) != 1 {
+		t.Fatalf("expected the rule once, got %v", got)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	sel := syntheticSelection()
+	for _, n := range []int{10, 100, 1000, 10000} {
+		engine, err := NewEngineWithRules(syntheticRuleSet(n), nil, 0)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		b.Run(fmt.Sprintf("rules=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := engine.Validate(context.Background(), sel); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFullScan is the pre-index baseline: every rule runs for every
+// selection.
+func BenchmarkFullScan(b *testing.B) {
+	sel := syntheticSelection()
+	for _, n := range []int{10, 100, 1000, 10000} {
+		rules := compileRules(syntheticRuleSet(n).Rules)
+		b.Run(fmt.Sprintf("rules=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f := newFacts(sel)
+				for _, r := range rules {
+					r.c.evaluate(f)
+				}
+			}
+		})
+	}
+}
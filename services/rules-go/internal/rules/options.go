@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+)
+
+// optionCatalog is the shared option catalog category rules resolve against.
+var optionCatalog = catalog.Default()
+
+// expand resolves the rule's category into option IDs so category rules
+// compile, index and analyze exactly like rules listing their options.
+func (r Rule) expand() Rule {
+	if r.Category == "" {
+		return r
+	}
+	ids := optionCatalog.InCategory(r.Category)
+	options := make([]string, 0, len(r.Options)+len(ids))
+	options = append(options, r.Options...)
+	for _, id := range ids {
+		if !stringIn(options, id) {
+			options = append(options, id)
+		}
+	}
+	r.Options = options
+	return r
+}
+
+// optionQuantityRule keeps every catalogued option within its allowed
+// quantities. Options missing from the catalog are left to pricing.
+func optionQuantityRule(c *catalog.Catalog) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		for i, opt := range sel.Options {
+			entry, ok := c.Lookup(opt.ID)
+			if !ok {
+				continue
+			}
+			qty := opt.Quantity
+			if qty <= 0 {
+				qty = 1
+			}
+			if entry.AllowsQuantity(qty) {
+				continue
+			}
+			code, message := "option.quantity.max", fmt.Sprintf("%s allows at most %d (requested %d)", entry.Name, entry.MaxQuantity, qty)
+			if qty < entry.MinQuantity {
+				code, message = "option.quantity.min", fmt.Sprintf("%s needs at least %d (requested %d)", entry.Name, entry.MinQuantity, qty)
+			}
+			return Violation{
+				Code:     code,
+				Severity: "error",
+				Message:  message,
+				Paths:    []string{pointer("options", i, "quantity")},
+				Options:  []string{opt.ID},
+				Params:   map[string]any{"option": opt.ID, "name": entry.Name, "min": entry.MinQuantity, "max": entry.MaxQuantity, "requested": qty},
+			}, true
+		}
+		return Violation{}, false
+	})
+}
+
+func stringIn(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+// AllMarkets lets a pack apply regardless of the selection's market.
+const AllMarkets = "*"
+
+// RulePack is a named group of rules layered on top of the base rules for the
+// markets it lists. Pack IDs are namespaced by region, e.g. "us-ada".
+type RulePack struct {
+	ID      string   `json:"id"`
+	Markets []string `json:"markets"`
+	Rules   []Rule   `json:"rules"`
+}
+
+// RegionalPacks returns the built-in market packs covering electrical, gas and
+// accessibility requirements that differ between regions.
+func RegionalPacks() []RulePack {
+	return []RulePack{
+		{
+			ID:      "us-ada",
+			Markets: []string{"us"},
+			Rules: []Rule{
+				{ID: "us-ada-walkway", Kind: RuleWalkway, MinMM: 1016},
+				{ID: "us-ada-counter-height", Kind: RuleMaxHeight, Severity: "warning", MaxMM: 864},
+			},
+		},
+		{
+			ID:      "eu-base",
+			Markets: []string{"at", "be", "de", "dk", "es", "fi", "fr", "ie", "it", "nl", "pl", "pt", "se"},
+			Rules: []Rule{
+				{ID: "eu-base-cooking-circuit", Kind: RuleMaxAppliances, Severity: "error", Options: []string{"range-upgrade", "cooktop-induction"}, Limit: 1},
+			},
+		},
+		{
+			ID:      "uk",
+			Markets: []string{"uk", "gb"},
+			Rules: []Rule{
+				{ID: "uk-island-gas-range", Kind: RuleForbidOptions, Layout: "island", Options: []string{"range-upgrade"}},
+				{ID: "uk-cooking-circuit", Kind: RuleMaxAppliances, Severity: "error", Options: []string{"range-upgrade", "cooktop-induction"}, Limit: 1},
+			},
+		},
+	}
+}
+
+type compiledPack struct {
+	id      string
+	markets map[string]struct{}
+	rules   ruleIndex
+}
+
+func compilePacks(packs []RulePack) []compiledPack {
+	compiled := make([]compiledPack, 0, len(packs))
+	for _, p := range packs {
+		markets := make(map[string]struct{}, len(p.Markets))
+		for _, m := range p.Markets {
+			markets[configurator.NormalizeMarket(m)] = struct{}{}
+		}
+		compiled = append(compiled, compiledPack{
+			id:      p.ID,
+			markets: markets,
+			rules:   newRuleIndex(compileRules(p.Rules)),
+		})
+	}
+	return compiled
+}
+
+func (p compiledPack) appliesTo(market string) bool {
+	if _, ok := p.markets[AllMarkets]; ok {
+		return true
+	}
+	if market == "" {
+		return false
+	}
+	_, ok := p.markets[market]
+	return ok
+}
+
+// maxHeightRule caps the worktop height given in the selection's dimensions.
+func maxHeightRule(max int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		height := sel.Dimensions.HeightMM
+		if height == 0 || height <= max {
+			return Violation{}, false
+		}
+		return Violation{
+			Code:     "dimension.height",
+			Severity: "error",
+			Message:  fmt.Sprintf("worktop height %dmm exceeds %dmm", height, max),
+			Paths:    []string{pointer("dimensions", "heightMm")},
+			Params:   map[string]any{"max": max, "requested": height},
+		}, true
+	})
+}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMarketSelectsRegionalPacks(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	base := Selection{
+		Module:     "galley",
+		Layout:     "island",
+		Finish:     "matte",
+		Dimensions: Dimensions{LengthMM: 5000, HeightMM: 900},
+		Options: []SelectionOption{
+			{ID: "range-upgrade", Quantity: 1},
+			{ID: "cooktop-induction", Quantity: 1},
+		},
+	}
+
+	cases := []struct {
+		market   string
+		packs    []string
+		code     string
+		blocking bool
+	}{
+		{market: "", packs: []string{}, blocking: false},
+		{market: "en-US", packs: []string{"us-ada"}, code: "dimension.height", blocking: false},
+		{market: "de", packs: []string{"eu-base"}, code: "appliance.limit", blocking: true},
+		{market: "en_GB", packs: []string{"uk"}, code: "layout.blocked", blocking: true},
+	}
+
+	for _, tc := range cases {
+		sel := base
+		sel.Market = tc.market
+		res, err := engine.Validate(context.Background(), sel)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.market, err)
+		}
+		if !reflect.DeepEqual(res.Packs, tc.packs) {
+			t.Fatalf("%q: expected packs %v, got %v", tc.market, tc.packs, res.Packs)
+		}
+		if tc.code != "" && !hasViolation(res, tc.code) {
+			t.Fatalf("%q: expected %s, got %+v", tc.market, tc.code, res.Violations)
+		}
+		if res.Blocking != tc.blocking {
+			t.Fatalf("%q: expected blocking=%v, got %+v", tc.market, tc.blocking, res.Violations)
+		}
+	}
+}
+
+func TestRuleSetRejectsPackWithoutMarkets(t *testing.T) {
+	set := DefaultRuleSet().WithPack(RulePack{ID: "orphan", Rules: []Rule{{ID: "orphan-limit", Kind: RuleMaxHeight, MaxMM: 900}}})
+	if err := set.Validate(); err == nil {
+		t.Fatalf("expected pack without markets to be rejected")
+	}
+}
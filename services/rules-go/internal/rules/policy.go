@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SeverityPolicy adjusts rule outcomes for one channel or tenant (retail web,
+// showroom designer, admin, ...). Code patterns match exactly, by prefix
+// ("room.*") or everything ("*").
+type SeverityPolicy struct {
+	ID string `json:"id"`
+	// Remap changes the severity of matching violations.
+	Remap map[string]string `json:"remap,omitempty"`
+	// Suppress drops matching violations from the result.
+	Suppress []string `json:"suppress,omitempty"`
+	// Overridable errors stop blocking once the caller echoes the ackToken
+	// issued for them in Selection.Acknowledgements.
+	Overridable []string `json:"overridable,omitempty"`
+}
+
+// DefaultPolicies returns the built-in channel policies.
+func DefaultPolicies() []SeverityPolicy {
+	return []SeverityPolicy{
+		{
+			ID:    "retail-web",
+			Remap: map[string]string{"appliance.limit": "error"},
+		},
+		{
+			ID:          "showroom-designer",
+			Suppress:    []string{"ergonomics.distance.*"},
+			Overridable: []string{"dimension.*", "room.walkway", "room.ceiling"},
+		},
+		{
+			ID:    "admin",
+			Remap: map[string]string{"*": "warning"},
+		},
+	}
+}
+
+// IssuesAckTokens reports whether any policy marks errors overridable, which
+// makes the engine sign acknowledgement tokens.
+func (s RuleSet) IssuesAckTokens() bool {
+	for _, p := range s.Policies {
+		if len(p.Overridable) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePolicies checks policy IDs are unique and remapped severities are known.
+func validatePolicies(policies []SeverityPolicy) error {
+	seen := make(map[string]struct{}, len(policies))
+	for _, p := range policies {
+		if p.ID == "" {
+			return fmt.Errorf("policy id is required")
+		}
+		if _, dup := seen[p.ID]; dup {
+			return fmt.Errorf("policy %s: duplicate id", p.ID)
+		}
+		seen[p.ID] = struct{}{}
+		for code, severity := range p.Remap {
+			switch severity {
+			case "error", "warning", "info":
+			default:
+				return fmt.Errorf("policy %s: unknown severity %q for %s", p.ID, severity, code)
+			}
+		}
+	}
+	return nil
+}
+
+// applyPolicy runs after the cache lookup so one cached, policy-free result
+// serves every channel. It rewrites severities, drops suppressed codes,
+// issues or verifies acknowledgement tokens, and recomputes Blocking.
+func (e *Engine) applyPolicy(res *ValidationResult, sel Selection) {
+	view := e.policyView(sel)
+	if view.policy == nil {
+		res.Blocking = blocks(res.Violations)
+		return
+	}
+	res.Policy = view.policy.ID
+
+	kept := make([]Violation, 0, len(res.Violations))
+	for _, v := range res.Violations {
+		if v, ok := view.apply(v); ok {
+			kept = append(kept, v)
+		}
+	}
+	res.Violations = kept
+	res.Blocking = blocks(kept)
+}
+
+// policyView applies the selection's channel policy to single violations.
+type policyView struct {
+	engine *Engine
+	policy *SeverityPolicy
+	acks   map[string]struct{}
+	key    string
+}
+
+func (e *Engine) policyView(sel Selection) policyView {
+	policy, ok := e.policies[strings.ToLower(strings.TrimSpace(sel.Channel))]
+	if !ok {
+		return policyView{engine: e}
+	}
+	acks := make(map[string]struct{}, len(sel.Acknowledgements))
+	for _, token := range sel.Acknowledgements {
+		acks[token] = struct{}{}
+	}
+	return policyView{engine: e, policy: &policy, acks: acks, key: sel.Fingerprint()}
+}
+
+// apply returns the violation as the channel sees it, or false when the
+// policy suppresses it.
+func (p policyView) apply(v Violation) (Violation, bool) {
+	if p.policy == nil {
+		return v, true
+	}
+	if matchesAny(p.policy.Suppress, v.Code) {
+		return Violation{}, false
+	}
+	if severity, ok := lookupPattern(p.policy.Remap, v.Code); ok {
+		v.Severity = severity
+	}
+	if strings.EqualFold(v.Severity, "error") && matchesAny(p.policy.Overridable, v.Code) {
+		v.Overridable = true
+		token := p.engine.ackToken(p.policy.ID, v.Code, p.key)
+		if _, acked := p.acks[token]; acked {
+			v.Acknowledged = true
+		} else {
+			v.AckToken = token
+		}
+	}
+	return v, true
+}
+
+// ackToken binds an acknowledgement to the policy, the violation code and the
+// exact selection so it cannot be replayed against a different configuration.
+func (e *Engine) ackToken(policyID, code, selectionKey string) string {
+	mac := hmac.New(sha256.New, e.ackSecret)
+	mac.Write([]byte(policyID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(code))
+	mac.Write([]byte{0})
+	mac.Write([]byte(selectionKey))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func blocks(violations []Violation) bool {
+	for _, v := range violations {
+		if strings.EqualFold(v.Severity, "error") && !v.Acknowledged {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, code string) bool {
+	for _, p := range patterns {
+		if codeMatches(p, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupPattern prefers an exact code, then the longest matching pattern.
+func lookupPattern(patterns map[string]string, code string) (string, bool) {
+	if v, ok := patterns[code]; ok {
+		return v, true
+	}
+	best, value := -1, ""
+	for p, v := range patterns {
+		if codeMatches(p, code) && len(p) > best {
+			best, value = len(p), v
+		}
+	}
+	return value, best >= 0
+}
+
+func codeMatches(pattern, code string) bool {
+	switch {
+	case pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, ".*"):
+		return strings.HasPrefix(code, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == code
+	}
+}
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+)
+
+func applianceHeavySelection(channel string) Selection {
+	return Selection{
+		Module:  "galley",
+		Layout:  "island",
+		Finish:  "matte",
+		Channel: channel,
+		Options: []SelectionOption{
+			{ID: "range-upgrade", Quantity: 2},
+			{ID: "cooktop-induction", Quantity: 2},
+		},
+	}
+}
+
+func TestPolicyEscalatesAndDowngradesPerChannel(t *testing.T) {
+	engine := NewEngine(cache.NewMemoryCache(), time.Minute)
+	ctx := context.Background()
+
+	res, err := engine.Validate(ctx, applianceHeavySelection(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Blocking || res.Policy != "" {
+		t.Fatalf("appliance limit should warn without a policy, got %+v", res)
+	}
+
+	res, err = engine.Validate(ctx, applianceHeavySelection("retail-web"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Cached {
+		t.Fatalf("policies must reuse the policy-free cache entry")
+	}
+	if !res.Blocking || res.Policy != "retail-web" {
+		t.Fatalf("retail policy should escalate appliance.limit, got %+v", res)
+	}
+
+	blocked := Selection{Module: "galley", Layout: "linear", Channel: "admin", Options: []SelectionOption{{ID: "island-counter", Quantity: 1}}}
+	res, err = engine.Validate(ctx, blocked)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Blocking || res.Violations[0].Severity != "warning" {
+		t.Fatalf("admin policy should downgrade errors, got %+v", res)
+	}
+}
+
+func TestOverridableErrorsNeedAcknowledgement(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := Selection{Module: "galley", Layout: "u-shape", Channel: "showroom-designer", Dimensions: Dimensions{LengthMM: 2000}}
+
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Blocking {
+		t.Fatalf("unacknowledged override should still block")
+	}
+	v := res.Violations[0]
+	if !v.Overridable || v.AckToken == "" {
+		t.Fatalf("expected an ack token for the overridable error, got %+v", v)
+	}
+
+	sel.Acknowledgements = []string{v.AckToken}
+	res, err = engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Blocking || !res.Violations[0].Acknowledged {
+		t.Fatalf("acknowledged override should not block, got %+v", res)
+	}
+
+	other := sel
+	other.Dimensions.LengthMM = 2100
+	res, err = engine.Validate(context.Background(), other)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Blocking {
+		t.Fatalf("ack token must not carry over to a different configuration")
+	}
+}
@@ -0,0 +1,287 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
+)
+
+// Room geometry is part of the shared configuration model.
+type (
+	Room          = configurator.Room
+	Wall          = configurator.Wall
+	Opening       = configurator.Opening
+	Placement     = configurator.Placement
+	Island        = configurator.Island
+	WallClearance = configurator.WallClearance
+)
+
+// Placement tiers and opening kinds, re-exported for rule authors.
+const (
+	TierBase      = configurator.TierBase
+	TierWall      = configurator.TierWall
+	TierTall      = configurator.TierTall
+	OpeningDoor   = configurator.OpeningDoor
+	OpeningWindow = configurator.OpeningWindow
+)
+
+func overlaps(aStart, aWidth, bStart, bWidth int) bool {
+	return aStart < bStart+bWidth && bStart < aStart+aWidth
+}
+
+// merged folds every offender a rule found into one violation, so the
+// one-violation-per-rule model holds while clients still see each input to
+// fix. The first offender sets the code and message; the paths and options
+// of the rest are appended. params["count"] reports how many offenders there
+// were and params["more"] how many beyond the first, which the catalog
+// templates render so localized messages keep the count.
+func merged(found []Violation) (Violation, bool) {
+	if len(found) == 0 {
+		return Violation{}, false
+	}
+	v := found[0]
+	params := make(map[string]any, len(v.Params)+2)
+	for k, val := range v.Params {
+		params[k] = val
+	}
+	params["count"] = len(found)
+	params["more"] = len(found) - 1
+	v.Params = params
+	if len(found) == 1 {
+		return v, true
+	}
+	var paths, options []string
+	seenPath := make(map[string]struct{}, len(v.Paths))
+	seenOption := make(map[string]struct{}, len(v.Options))
+	for _, f := range found {
+		for _, p := range f.Paths {
+			if _, dup := seenPath[p]; !dup {
+				seenPath[p] = struct{}{}
+				paths = append(paths, p)
+			}
+		}
+		for _, o := range f.Options {
+			if _, dup := seenOption[o]; !dup {
+				seenOption[o] = struct{}{}
+				options = append(options, o)
+			}
+		}
+	}
+	v.Paths = paths
+	v.Options = options
+	v.Message = fmt.Sprintf("%s (and %d more)", v.Message, len(found)-1)
+	return v, true
+}
+
+// wallFitRule checks every placement sits on a known wall, inside its length,
+// and does not collide with another unit on the same tier.
+func wallFitRule() constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil {
+			return Violation{}, false
+		}
+		room := sel.Room
+		var found []Violation
+		for i, p := range room.Placements {
+			wall, ok := room.Wall(p.WallID)
+			if !ok {
+				found = append(found, Violation{
+					Code:     "room.wall.unknown",
+					Severity: "error",
+					Message:  fmt.Sprintf("%s is placed on unknown wall %s", p.Module, p.WallID),
+					Paths:    []string{pointer("room", "placements", i, "wallId")},
+					Params:   map[string]any{"wall": p.WallID},
+				})
+				continue
+			}
+			if p.OffsetMM < 0 || p.OffsetMM+p.WidthMM > wall.LengthMM {
+				found = append(found, Violation{
+					Code:     "room.wall.overflow",
+					Severity: "error",
+					Message:  fmt.Sprintf("%s does not fit on wall %s (%dmm long)", p.Module, wall.ID, wall.LengthMM),
+					Paths: []string{
+						pointer("room", "placements", i, "offsetMm"),
+						pointer("room", "placements", i, "widthMm"),
+						pointer("room", "walls", room.WallIndex(wall.ID), "lengthMm"),
+					},
+					Params: map[string]any{"wall": wall.ID, "max": wall.LengthMM, "requested": p.OffsetMM + p.WidthMM},
+				})
+			}
+			for j := i + 1; j < len(room.Placements); j++ {
+				q := room.Placements[j]
+				if q.WallID != p.WallID || !overlaps(p.OffsetMM, p.WidthMM, q.OffsetMM, q.WidthMM) {
+					continue
+				}
+				if (p.OccupiesFloor() && q.OccupiesFloor()) || (p.OccupiesUpper() && q.OccupiesUpper()) {
+					found = append(found, Violation{
+						Code:     "room.placement.overlap",
+						Severity: "error",
+						Message:  fmt.Sprintf("%s overlaps %s on wall %s", p.Module, q.Module, wall.ID),
+						Paths:    []string{pointer("room", "placements", i), pointer("room", "placements", j)},
+						Params:   map[string]any{"wall": wall.ID},
+					})
+				}
+			}
+		}
+		return merged(found)
+	})
+}
+
+// openingClearanceRule keeps doors clear at every tier and windows clear of
+// wall and tall units.
+func openingClearanceRule() constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil {
+			return Violation{}, false
+		}
+		var found []Violation
+		for i, p := range sel.Room.Placements {
+			w := sel.Room.WallIndex(p.WallID)
+			if w < 0 {
+				continue
+			}
+			wall := sel.Room.Walls[w]
+			for k, o := range wall.Openings {
+				if !overlaps(p.OffsetMM, p.WidthMM, o.OffsetMM, o.WidthMM) {
+					continue
+				}
+				if o.Kind == OpeningDoor || p.OccupiesUpper() {
+					found = append(found, Violation{
+						Code:     "room.opening." + o.Kind,
+						Severity: "error",
+						Message:  fmt.Sprintf("%s blocks a %s on wall %s", p.Module, o.Kind, wall.ID),
+						Paths:    []string{pointer("room", "placements", i), pointer("room", "walls", w, "openings", k)},
+						Params:   map[string]any{"wall": wall.ID, "opening": o.Kind},
+					})
+				}
+			}
+		}
+		return merged(found)
+	})
+}
+
+// ceilingFitRule checks tall units plus the required headroom fit under the
+// ceiling.
+func ceilingFitRule(headroomMM int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil || sel.Room.CeilingHeightMM == 0 {
+			return Violation{}, false
+		}
+		var found []Violation
+		for i, p := range sel.Room.Placements {
+			if p.Tier != TierTall || p.HeightMM == 0 {
+				continue
+			}
+			if p.HeightMM+headroomMM > sel.Room.CeilingHeightMM {
+				found = append(found, Violation{
+					Code:     "room.ceiling",
+					Severity: "error",
+					Message:  fmt.Sprintf("%s is %dmm tall but the ceiling is %dmm", p.Module, p.HeightMM, sel.Room.CeilingHeightMM),
+					Paths:    []string{pointer("room", "placements", i, "heightMm"), pointer("room", "ceilingHeightMm")},
+					Params:   map[string]any{"max": sel.Room.CeilingHeightMM - headroomMM, "requested": p.HeightMM},
+				})
+			}
+		}
+		return merged(found)
+	})
+}
+
+// walkwayClearanceRule measures the gap between the island and the front of
+// the deepest floor unit on each facing wall, and checks the island footprint
+// plus both clearances fits between opposite walls.
+func walkwayClearanceRule(minMM int) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		if sel.Room == nil || sel.Room.Island == nil {
+			return Violation{}, false
+		}
+		var found []Violation
+		for i, c := range sel.Room.Island.Clearances {
+			depth := 0
+			for _, p := range sel.Room.Placements {
+				if p.WallID == c.WallID && p.OccupiesFloor() && p.Depth() > depth {
+					depth = p.Depth()
+				}
+			}
+			if walkway := c.DistanceMM - depth; walkway < minMM {
+				found = append(found, Violation{
+					Code:     "room.walkway",
+					Severity: "error",
+					Message:  fmt.Sprintf("walkway to wall %s is %dmm, minimum is %dmm", c.WallID, walkway, minMM),
+					Paths:    []string{pointer("room", "island", "clearances", i, "distanceMm")},
+					Params:   map[string]any{"wall": c.WallID, "min": minMM, "requested": walkway},
+				})
+			}
+		}
+		found = append(found, islandFit(*sel.Room)...)
+		return merged(found)
+	})
+}
+
+// islandFit checks each pair of clearances measured to opposite walls. The
+// island's length faces the first clearance's wall, so its depth spans the
+// gap to walls parallel to that one and its length spans the others. Walls
+// without headings are never opposite, so rooms without plan positions skip
+// the check.
+func islandFit(room Room) []Violation {
+	island := room.Island
+	if len(island.Clearances) < 2 {
+		return nil
+	}
+	first, ok := room.Wall(island.Clearances[0].WallID)
+	if !ok {
+		return nil
+	}
+	var found []Violation
+	for i, a := range island.Clearances {
+		wa, ok := room.Wall(a.WallID)
+		if !ok {
+			continue
+		}
+		for j := i + 1; j < len(island.Clearances); j++ {
+			b := island.Clearances[j]
+			wb, ok := room.Wall(b.WallID)
+			if !ok || !opposite(wa, wb) {
+				continue
+			}
+			span, field := island.LengthMM, "lengthMm"
+			if parallel(wa, first) {
+				span, field = island.DepthMM, "depthMm"
+			}
+			gap := separation(wa, wb)
+			if need := a.DistanceMM + span + b.DistanceMM; need > gap {
+				found = append(found, Violation{
+					Code:     "room.island.fit",
+					Severity: "error",
+					Message:  fmt.Sprintf("island needs %dmm between walls %s and %s but they are %dmm apart", need, wa.ID, wb.ID, gap),
+					Paths: []string{
+						pointer("room", "island", field),
+						pointer("room", "island", "clearances", i, "distanceMm"),
+						pointer("room", "island", "clearances", j, "distanceMm"),
+					},
+					Params: map[string]any{"wall": wa.ID, "otherWall": wb.ID, "max": gap, "requested": need},
+				})
+			}
+		}
+	}
+	return found
+}
+
+func headingDelta(a, b Wall) int {
+	d := (a.HeadingDeg - b.HeadingDeg) % 360
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+func opposite(a, b Wall) bool { return headingDelta(a, b) == 180 }
+
+func parallel(a, b Wall) bool { return headingDelta(a, b)%180 == 0 }
+
+// separation is the perpendicular distance between two parallel walls.
+func separation(a, b Wall) int {
+	rad := float64(a.HeadingDeg) * math.Pi / 180
+	dx, dy := float64(b.XMM-a.XMM), float64(b.YMM-a.YMM)
+	return int(math.Round(math.Abs(math.Cos(rad)*dy - math.Sin(rad)*dx)))
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"context"
+	"testing"
+)
+
+func roomSelection(room Room) Selection {
+	return Selection{
+		ConfigurationID: "cfg-room",
+		Module:          "galley",
+		Layout:          "l-shape",
+		Finish:          "matte",
+		Room:            &room,
+	}
+}
+
+func TestRoomRulesAcceptWellPlannedKitchen(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := roomSelection(Room{
+		CeilingHeightMM: 2500,
+		Walls: []Wall{
+			{ID: "north", LengthMM: 3600, Openings: []Opening{{Kind: OpeningWindow, OffsetMM: 1200, WidthMM: 900}}},
+			{ID: "east", LengthMM: 2400, Openings: []Opening{{Kind: OpeningDoor, OffsetMM: 1500, WidthMM: 900}}},
+		},
+		Placements: []Placement{
+			{Module: "sink-base", WallID: "north", Tier: TierBase, OffsetMM: 1200, WidthMM: 900},
+			{Module: "wall-left", WallID: "north", Tier: TierWall, OffsetMM: 0, WidthMM: 1200},
+			{Module: "pantry", WallID: "east", Tier: TierTall, OffsetMM: 0, WidthMM: 600, HeightMM: 2300},
+		},
+		Island: &Island{LengthMM: 2000, DepthMM: 900, Clearances: []WallClearance{{WallID: "north", DistanceMM: 1700}}},
+	})
+
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", res.Violations)
+	}
+}
+
+func TestRoomRulesDetectGeometryProblems(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	walls := []Wall{
+		{ID: "north", LengthMM: 3000, Openings: []Opening{{Kind: OpeningWindow, OffsetMM: 1000, WidthMM: 800}}},
+		{ID: "east", LengthMM: 2400, Openings: []Opening{{Kind: OpeningDoor, OffsetMM: 1500, WidthMM: 900}}},
+	}
+
+	cases := map[string]Room{
+		"room.wall.overflow": {Walls: walls, Placements: []Placement{
+			{Module: "run", WallID: "north", Tier: TierBase, OffsetMM: 2000, WidthMM: 1200},
+		}},
+		"room.placement.overlap": {Walls: walls, Placements: []Placement{
+			{Module: "a", WallID: "north", Tier: TierBase, OffsetMM: 0, WidthMM: 600},
+			{Module: "b", WallID: "north", Tier: TierTall, OffsetMM: 300, WidthMM: 600},
+		}},
+		"room.opening.door": {Walls: walls, Placements: []Placement{
+			{Module: "base", WallID: "east", Tier: TierBase, OffsetMM: 1200, WidthMM: 600},
+		}},
+		"room.opening.window": {Walls: walls, Placements: []Placement{
+			{Module: "upper", WallID: "north", Tier: TierWall, OffsetMM: 1200, WidthMM: 600},
+		}},
+		"room.ceiling": {CeilingHeightMM: 2200, Walls: walls, Placements: []Placement{
+			{Module: "pantry", WallID: "east", Tier: TierTall, OffsetMM: 0, WidthMM: 600, HeightMM: 2300},
+		}},
+		"room.walkway": {Walls: walls, Placements: []Placement{
+			{Module: "base", WallID: "north", Tier: TierBase, OffsetMM: 0, WidthMM: 900},
+		}, Island: &Island{LengthMM: 1800, DepthMM: 900, Clearances: []WallClearance{{WallID: "north", DistanceMM: 1400}}}},
+	}
+
+	for code, room := range cases {
+		res, err := engine.Validate(context.Background(), roomSelection(room))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", code, err)
+		}
+		if !hasViolation(res, code) {
+			t.Fatalf("%s: expected violation, got %+v", code, res.Violations)
+		}
+		if !res.Blocking {
+			t.Fatalf("%s: geometry violations should block", code)
+		}
+	}
+}
+
+func TestRoomValidateRejectsMalformedRooms(t *testing.T) {
+	room := Room{Walls: []Wall{{ID: "north", LengthMM: 0}}}
+	if err := roomSelection(room).Validate(); err == nil {
+		t.Fatalf("expected zero-length wall to be rejected")
+	}
+}
+
+func hasViolation(res ValidationResult, code string) bool {
+	for _, v := range res.Violations {
+		if v.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRoomRulesReportEveryOffender(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	sel := roomSelection(Room{
+		CeilingHeightMM: 2200,
+		Walls:           []Wall{{ID: "east", LengthMM: 2400}},
+		Placements: []Placement{
+			{Module: "pantry-a", WallID: "east", Tier: TierTall, OffsetMM: 0, WidthMM: 600, HeightMM: 2300},
+			{Module: "pantry-b", WallID: "east", Tier: TierTall, OffsetMM: 600, WidthMM: 600, HeightMM: 2250},
+		},
+	})
+
+	res, err := engine.Validate(context.Background(), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, v := range res.Violations {
+		if v.Code != "room.ceiling" {
+			continue
+		}
+		if len(v.Paths) != 3 || v.Params["count"] != 2 {
+			t.Fatalf("expected both tall units reported, got %+v", v)
+		}
+		return
+	}
+	t.Fatalf("expected a ceiling violation, got %+v", res.Violations)
+}
+
+func TestIslandFootprintMustFitBetweenOppositeWalls(t *testing.T) {
+	engine := NewEngine(nil, 0)
+	room := func(depthMM int) Room {
+		return Room{
+			Walls: []Wall{
+				{ID: "south", LengthMM: 4000},
+				{ID: "north", LengthMM: 4000, XMM: 4000, YMM: 3800, HeadingDeg: 180},
+			},
+			Island: &Island{LengthMM: 2000, DepthMM: depthMM, Clearances: []WallClearance{
+				{WallID: "south", DistanceMM: 1400},
+				{WallID: "north", DistanceMM: 1400},
+			}},
+		}
+	}
+
+	res, err := engine.Validate(context.Background(), roomSelection(room(900)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasViolation(res, "room.island.fit") {
+		t.Fatalf("a 900mm deep island fits in 3800mm, got %+v", res.Violations)
+	}
+
+	res, err = engine.Validate(context.Background(), roomSelection(room(1200)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, v := range res.Violations {
+		if v.Code == "room.island.fit" {
+			if v.Params["requested"] != 4000 || v.Params["max"] != 3800 || v.Paths[0] != "/room/island/depthMm" {
+				t.Fatalf("unexpected island fit violation: %+v", v)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the island to overflow, got %+v", res.Violations)
+}
@@ -0,0 +1,295 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/catalog"
+)
+
+// RuleKind identifies the constraint template a Rule compiles into.
+type RuleKind string
+
+const (
+	RuleRequireLayout RuleKind = "require-layout"
+	RuleForbidOptions RuleKind = "forbid-options"
+	RuleFinishOnly    RuleKind = "finish-compatibility"
+	RuleDimensionBand RuleKind = "dimension-band"
+	RuleMaxAppliances RuleKind = "max-appliances"
+	RuleMaxHeight     RuleKind = "max-height"
+	RuleWallFit       RuleKind = "wall-fit"
+	RuleOpenings      RuleKind = "opening-clearance"
+	RuleCeilingFit    RuleKind = "ceiling-fit"
+	RuleWalkway       RuleKind = "walkway-clearance"
+
+	RuleWorkTriangle      RuleKind = "work-triangle"
+	RuleLandingArea       RuleKind = "landing-area"
+	RuleApplianceDistance RuleKind = "appliance-distance"
+
+	RuleOptionQuantity RuleKind = "option-quantity"
+)
+
+// ergonomic reports whether the kind is an advisory ergonomics check. These
+// never block, so they may only report warning or info.
+func (k RuleKind) ergonomic() bool {
+	return k == RuleWorkTriangle || k == RuleLandingArea || k == RuleApplianceDistance
+}
+
+// Rule is the declarative form of a constraint. Keeping rules as plain data
+// lets us load them from files and analyze a rule set before it goes live.
+type Rule struct {
+	ID          string   `json:"id"`
+	Kind        RuleKind `json:"kind"`
+	Severity    string   `json:"severity,omitempty"`
+	Layout      string   `json:"layout,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Finishes    []string `json:"finishes,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	MinMM       int      `json:"minMm,omitempty"`
+	MaxMM       int      `json:"maxMm,omitempty"`
+	OtherSideMM int      `json:"otherSideMm,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+}
+
+// RuleSet is an ordered, versioned collection of base rules plus packs that
+// layer on top of them for specific markets.
+type RuleSet struct {
+	Version  string           `json:"version"`
+	Rules    []Rule           `json:"rules"`
+	Packs    []RulePack       `json:"packs,omitempty"`
+	Policies []SeverityPolicy `json:"policies,omitempty"`
+}
+
+// DefaultRuleSet returns the built-in rules the engine ships with.
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		Version: "builtin",
+		Rules: []Rule{
+			{ID: "island-counter-requires-island", Kind: RuleRequireLayout, Layout: "island", Options: []string{"island-counter"}},
+			{ID: "linear-forbids-corner-units", Kind: RuleForbidOptions, Layout: "linear", Options: []string{"corner-carousel", "pull-out-pantry"}},
+			{ID: "glass-cabinet-finishes", Kind: RuleFinishOnly, Options: []string{"glass-cabinet"}, Finishes: []string{"gloss", "stainless"}},
+			{ID: "u-shape-length", Kind: RuleDimensionBand, Layout: "u-shape", MinMM: 3600, MaxMM: 9600},
+			{ID: "island-length", Kind: RuleDimensionBand, Layout: "island", MinMM: 4200, MaxMM: 12000},
+			{ID: "appliance-limit", Kind: RuleMaxAppliances, Category: catalog.CategoryAppliance, Limit: 3},
+			{ID: "room-wall-fit", Kind: RuleWallFit},
+			{ID: "room-openings", Kind: RuleOpenings},
+			{ID: "room-ceiling", Kind: RuleCeilingFit},
+			{ID: "room-walkway", Kind: RuleWalkway, MinMM: 1000},
+		},
+		Packs:    RegionalPacks(),
+		Policies: DefaultPolicies(),
+	}
+}
+
+// WithPack returns a copy of the set with the pack added. The pack ID is
+// folded into the version so cached results never leak across packs.
+func (s RuleSet) WithPack(pack RulePack) RuleSet {
+	packs := make([]RulePack, 0, len(s.Packs)+1)
+	packs = append(packs, s.Packs...)
+	packs = append(packs, pack)
+	return RuleSet{Version: s.Version + "+" + pack.ID, Rules: s.Rules, Packs: packs, Policies: s.Policies}
+}
+
+// LoadRuleSet reads a JSON rule set from disk.
+func LoadRuleSet(path string) (RuleSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RuleSet{}, err
+	}
+	defer f.Close()
+	return ParseRuleSet(f)
+}
+
+// ParseRuleSet decodes and validates a JSON rule set.
+func ParseRuleSet(r io.Reader) (RuleSet, error) {
+	var set RuleSet
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&set); err != nil {
+		return RuleSet{}, fmt.Errorf("decode rule set: %w", err)
+	}
+	if err := set.Validate(); err != nil {
+		return RuleSet{}, err
+	}
+	return set, nil
+}
+
+// Validate checks that every rule is well-formed and that rule and pack IDs
+// are unique across the whole set.
+func (s RuleSet) Validate() error {
+	seen := make(map[string]struct{}, len(s.Rules))
+	check := func(rules []Rule) error {
+		for i, rule := range rules {
+			if rule.ID == "" {
+				return fmt.Errorf("rule %d: id is required", i)
+			}
+			if _, dup := seen[rule.ID]; dup {
+				return fmt.Errorf("rule %s: duplicate id", rule.ID)
+			}
+			seen[rule.ID] = struct{}{}
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("rule %s: %w", rule.ID, err)
+			}
+		}
+		return nil
+	}
+	if err := check(s.Rules); err != nil {
+		return err
+	}
+	packs := make(map[string]struct{}, len(s.Packs))
+	for _, p := range s.Packs {
+		if p.ID == "" {
+			return errors.New("pack id is required")
+		}
+		if _, dup := packs[p.ID]; dup {
+			return fmt.Errorf("pack %s: duplicate id", p.ID)
+		}
+		packs[p.ID] = struct{}{}
+		if len(p.Markets) == 0 {
+			return fmt.Errorf("pack %s: at least one market is required", p.ID)
+		}
+		if err := check(p.Rules); err != nil {
+			return fmt.Errorf("pack %s: %w", p.ID, err)
+		}
+	}
+	return validatePolicies(s.Policies)
+}
+
+// Validate checks the fields required by the rule's kind.
+func (r Rule) Validate() error {
+	switch r.Severity {
+	case "", "error", "warning", "info":
+	default:
+		return fmt.Errorf("unknown severity %q", r.Severity)
+	}
+	if r.Severity == "error" && r.Kind.ergonomic() {
+		return fmt.Errorf("%s is advisory and cannot use severity error", r.Kind)
+	}
+	if r.Category != "" {
+		if r.Kind != RuleForbidOptions && r.Kind != RuleMaxAppliances {
+			return fmt.Errorf("%s does not support categories", r.Kind)
+		}
+		if !optionCatalog.HasCategory(r.Category) {
+			return fmt.Errorf("unknown category %q", r.Category)
+		}
+	}
+	switch r.Kind {
+	case RuleRequireLayout:
+		if r.Layout == "" || len(r.Options) != 1 {
+			return errors.New("require-layout needs a layout and exactly one option")
+		}
+	case RuleForbidOptions:
+		if r.Layout == "" || (len(r.Options) == 0 && r.Category == "") {
+			return errors.New("forbid-options needs a layout and at least one option or a category")
+		}
+	case RuleFinishOnly:
+		if len(r.Options) != 1 {
+			return errors.New("finish-compatibility needs exactly one option")
+		}
+	case RuleDimensionBand:
+		if r.Layout == "" {
+			return errors.New("dimension-band needs a layout")
+		}
+		if r.MinMM < 0 || r.MaxMM <= 0 || r.MinMM > r.MaxMM {
+			return errors.New("dimension-band needs 0 <= minMm <= maxMm and a positive maxMm")
+		}
+	case RuleMaxAppliances:
+		if len(r.Options) == 0 && r.Category == "" {
+			return errors.New("max-appliances needs at least one option or a category")
+		}
+	case RuleMaxHeight:
+		if r.MaxMM <= 0 {
+			return errors.New("max-height needs a positive maxMm")
+		}
+	case RuleWallFit, RuleOpenings, RuleOptionQuantity:
+	case RuleCeilingFit:
+		if r.MinMM < 0 {
+			return errors.New("ceiling-fit headroom must be >= 0")
+		}
+	case RuleWalkway:
+		if r.MinMM <= 0 {
+			return errors.New("walkway-clearance needs a positive minMm")
+		}
+	case RuleWorkTriangle:
+		if r.MinMM > r.MaxMM {
+			return errors.New("work-triangle needs minMm <= maxMm")
+		}
+	case RuleLandingArea:
+		if len(r.Roles) != 1 || r.MinMM <= 0 {
+			return errors.New("landing-area needs exactly one role and a positive minMm")
+		}
+	case RuleApplianceDistance:
+		if len(r.Roles) != 2 || r.MaxMM <= 0 {
+			return errors.New("appliance-distance needs two roles and a positive maxMm")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+	return nil
+}
+
+func (r Rule) compile() constraint {
+	var c constraint
+	switch r.Kind {
+	case RuleRequireLayout:
+		c = requireLayoutForOption(r.Options[0], r.Layout)
+	case RuleForbidOptions:
+		c = forbidOptionsForLayout(r.Layout, r.Options)
+	case RuleFinishOnly:
+		c = finishCompatibilityRule(r.Options[0], r.Finishes)
+	case RuleDimensionBand:
+		c = dimensionBandRule(r.Layout, r.MinMM, r.MaxMM)
+	case RuleMaxAppliances:
+		c = maxApplianceRule(r.Options, r.Limit)
+	case RuleMaxHeight:
+		c = maxHeightRule(r.MaxMM)
+	case RuleWallFit:
+		c = wallFitRule()
+	case RuleOpenings:
+		c = openingClearanceRule()
+	case RuleCeilingFit:
+		c = ceilingFitRule(r.MinMM)
+	case RuleWalkway:
+		c = walkwayClearanceRule(r.MinMM)
+	case RuleOptionQuantity:
+		c = optionQuantityRule(optionCatalog)
+	case RuleWorkTriangle:
+		c = workTriangleRule(r.MinMM, r.MaxMM, r.Limit)
+	case RuleLandingArea:
+		c = landingAreaRule(r.Roles[0], r.MinMM, r.OtherSideMM)
+	case RuleApplianceDistance:
+		c = applianceDistanceRule(r.Roles[0], r.Roles[1], r.MaxMM)
+	}
+	if r.Severity == "" {
+		return c
+	}
+	return withSeverity(c, r.Severity)
+}
+
+func withSeverity(c constraint, severity string) constraint {
+	return constraintFunc(func(sel *facts) (Violation, bool) {
+		violation, violated := c.evaluate(sel)
+		if violated {
+			violation.Severity = severity
+		}
+		return violation, violated
+	})
+}
+
+// effectiveSeverity reports the severity a rule's violations carry.
+func (r Rule) effectiveSeverity() string {
+	if r.Severity != "" {
+		return r.Severity
+	}
+	switch r.Kind {
+	case RuleMaxAppliances, RuleWorkTriangle, RuleLandingArea:
+		return "warning"
+	case RuleApplianceDistance:
+		return "info"
+	}
+	return "error"
+}
@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules")
+
+// RuleTrace records one rule evaluation when tracing is enabled.
+type RuleTrace struct {
+	RuleID        string   `json:"ruleId"`
+	Kind          RuleKind `json:"kind"`
+	Pack          string   `json:"pack,omitempty"`
+	Matched       bool     `json:"matched"`
+	DurationNanos int64    `json:"durationNanos"`
+	Inputs        []string `json:"inputs"`
+}
+
+type traceKey struct{}
+
+// WithTrace opts a validation into per-rule tracing. Traced validations skip
+// the cache read so every rule actually runs.
+func WithTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, traceKey{}, true)
+}
+
+func tracing(ctx context.Context) bool {
+	on, _ := ctx.Value(traceKey{}).(bool)
+	return on
+}
+
+// compiledRule keeps a rule's identity next to its constraint so evaluation
+// can be traced back to the declarative rule.
+type compiledRule struct {
+	id       string
+	kind     RuleKind
+	inputs   []string
+	triggers ruleTriggers
+	c        constraint
+}
+
+func compileRules(rules []Rule) []compiledRule {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		rule = rule.expand()
+		compiled = append(compiled, compiledRule{
+			id:       rule.ID,
+			kind:     rule.Kind,
+			inputs:   rule.inputs(),
+			triggers: rule.triggers(),
+			c:        rule.compile(),
+		})
+	}
+	return compiled
+}
+
+// inputs lists the selection fields, as JSON pointers, a rule kind reads.
+func (r Rule) inputs() []string {
+	switch r.Kind {
+	case RuleRequireLayout, RuleForbidOptions:
+		return []string{"/options", "/layout"}
+	case RuleFinishOnly:
+		return []string{"/options", "/finish"}
+	case RuleDimensionBand:
+		return []string{"/layout", "/dimensions/lengthMm"}
+	case RuleMaxAppliances:
+		return []string{"/options"}
+	case RuleOptionQuantity:
+		return []string{"/options"}
+	case RuleMaxHeight:
+		return []string{"/dimensions/heightMm"}
+	case RuleWallFit, RuleOpenings:
+		return []string{"/room/walls", "/room/placements"}
+	case RuleCeilingFit:
+		return []string{"/room/ceilingHeightMm", "/room/placements"}
+	case RuleWalkway:
+		return []string{"/room/island", "/room/placements"}
+	case RuleWorkTriangle, RuleLandingArea, RuleApplianceDistance:
+		return []string{"/room/walls", "/room/placements"}
+	}
+	return nil
+}
+
+// evaluator runs compiled rules, timing each one only when someone is
+// listening: the caller asked for a trace or the span is being recorded.
+type evaluator struct {
+	sel        *facts
+	span       trace.Span
+	collect    bool
+	timed      bool
+	violations []Violation
+	trace      []RuleTrace
+}
+
+func newEvaluator(ctx context.Context, sel *facts) *evaluator {
+	span := trace.SpanFromContext(ctx)
+	collect := tracing(ctx)
+	return &evaluator{
+		sel:        sel,
+		span:       span,
+		collect:    collect,
+		timed:      collect || span.IsRecording(),
+		violations: make([]Violation, 0),
+	}
+}
+
+func (ev *evaluator) run(pack string, index ruleIndex) {
+	for _, i := range index.candidates(ev.sel) {
+		r := index.rules[i]
+		if !ev.timed {
+			if violation, violated := r.c.evaluate(ev.sel); violated {
+				violation.Rule = r.id
+				ev.violations = append(ev.violations, violation)
+			}
+			continue
+		}
+
+		start := time.Now()
+		violation, violated := r.c.evaluate(ev.sel)
+		elapsed := time.Since(start)
+		if violated {
+			violation.Rule = r.id
+			ev.violations = append(ev.violations, violation)
+		}
+		ev.span.AddEvent("rule.evaluated", trace.WithAttributes(
+			attribute.String("rule.id", r.id),
+			attribute.String("rule.kind", string(r.kind)),
+			attribute.String("rule.pack", pack),
+			attribute.Bool("rule.matched", violated),
+			attribute.Int64("rule.duration_ns", elapsed.Nanoseconds()),
+		))
+		if ev.collect {
+			ev.trace = append(ev.trace, RuleTrace{
+				RuleID:        r.id,
+				Kind:          r.kind,
+				Pack:          pack,
+				Matched:       violated,
+				DurationNanos: elapsed.Nanoseconds(),
+				Inputs:        r.inputs,
+			})
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceReportsEveryRuleEvaluated(t *testing.T) {
+	engine := NewEngine(cache.NewMemoryCache(), time.Minute)
+	sel := Selection{
+		Module:  "galley",
+		Layout:  "linear",
+		Market:  "us",
+		Options: []SelectionOption{{ID: "island-counter", Quantity: 1}},
+	}
+
+	if _, err := engine.Validate(context.Background(), sel); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := engine.Validate(WithTrace(context.Background()), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Cached {
+		t.Fatalf("traced validation should bypass the cache")
+	}
+
+	// Only rules the selection can trigger run: the option-keyed base rule
+	// and the us-ada height cap. Room rules are skipped without a room.
+	if len(res.Trace) != 2 {
+		t.Fatalf("expected 2 traced rules, got %+v", res.Trace)
+	}
+	matched := 0
+	for _, rt := range res.Trace {
+		if rt.Matched {
+			matched++
+			if rt.RuleID != "island-counter-requires-island" {
+				t.Fatalf("unexpected matched rule %s", rt.RuleID)
+			}
+		}
+		if len(rt.Inputs) == 0 {
+			t.Fatalf("rule %s should list its inputs", rt.RuleID)
+		}
+	}
+	if matched != 1 {
+		t.Fatalf("expected exactly one matched rule, got %d", matched)
+	}
+	if last := res.Trace[len(res.Trace)-1]; last.Pack != "us-ada" {
+		t.Fatalf("expected pack rules to be tagged, got %+v", last)
+	}
+
+	plain, _ := engine.Validate(context.Background(), sel)
+	if plain.Trace != nil {
+		t.Fatalf("trace must be opt-in")
+	}
+}
+
+func TestRuleEvaluationsAreSpanEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+
+	engine := NewEngine(nil, 0)
+	sel := Selection{
+		Module:  "galley",
+		Layout:  "island",
+		Options: []SelectionOption{{ID: "island-counter", Quantity: 1}, {ID: "range-upgrade", Quantity: 1}},
+	}
+	res, err := engine.Validate(WithTrace(ctx), sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) == 0 {
+		t.Fatalf("expected spans to be recorded")
+	}
+	var validate sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if s.Name() == "rules.validate" {
+			validate = s
+		}
+	}
+	if validate == nil {
+		t.Fatalf("expected a rules.validate span")
+	}
+	if validate.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("rules.validate should be a child of the request span")
+	}
+	if got := len(validate.Events()); got == 0 || got != len(res.Trace) {
+		t.Fatalf("expected one event per evaluated rule, got %d for %d rules", got, len(res.Trace))
+	}
+}
@@ -1,95 +1,73 @@
 package rules
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
-	"errors"
-	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/configurator"
 )
 
-type SelectionOption struct {
-	ID       string `json:"id"`
-	Quantity int    `json:"quantity"`
-}
+// The selection model is shared with pricing through go-kit.
+type (
+	Selection       = configurator.Selection
+	SelectionOption = configurator.SelectionOption
+	Dimensions      = configurator.Dimensions
+)
 
-type Dimensions struct {
-	LengthMM int `json:"lengthMm"`
-	HeightMM int `json:"heightMm"`
+// validateSelection applies the shared schema plus the layout every rule set
+// keys on.
+func validateSelection(sel Selection) error {
+	return sel.Validate(configurator.FieldLayout)
 }
 
-type Selection struct {
-	ConfigurationID string            `json:"configurationId"`
-	Module          string            `json:"module"`
-	Layout          string            `json:"layout"`
-	Finish          string            `json:"finish"`
-	Options         []SelectionOption `json:"options"`
-	Dimensions      Dimensions        `json:"dimensions"`
-}
+// Violation describes a single blocking or warning state raised by Rule. Paths
+// are RFC 6901 JSON pointers into the submitted Selection so clients can
+// highlight the offending inputs; Options lists the affected option IDs and
+// Params carries the values behind the message (min, max, limit, requested,
+// ...).
+type Violation struct {
+	Rule     string         `json:"rule,omitempty"`
+	Code     string         `json:"code"`
+	Severity string         `json:"severity"`
+	Message  string         `json:"message"`
+	Paths    []string       `json:"paths,omitempty"`
+	Options  []string       `json:"options,omitempty"`
+	Params   map[string]any `json:"params,omitempty"`
 
-func (s Selection) Validate() error {
-	if s.Module == "" {
-		return errors.New("module is required")
-	}
-	if s.Layout == "" {
-		return errors.New("layout is required")
-	}
-	for _, opt := range s.Options {
-		if opt.ID == "" {
-			return errors.New("option id is required")
-		}
-	}
-	return nil
+	Overridable  bool   `json:"overridable,omitempty"`
+	AckToken     string `json:"ackToken,omitempty"`
+	Acknowledged bool   `json:"acknowledged,omitempty"`
 }
 
-func (s Selection) cacheKey() string {
-	type keyOpt struct {
-		ID       string
-		Quantity int
-	}
-	opts := make([]keyOpt, 0, len(s.Options))
-	for _, opt := range s.Options {
-		opts = append(opts, keyOpt{ID: opt.ID, Quantity: opt.Quantity})
-	}
-	sort.Slice(opts, func(i, j int) bool {
-		if opts[i].ID == opts[j].ID {
-			return opts[i].Quantity < opts[j].Quantity
+// pointer builds an RFC 6901 JSON pointer from field names and indexes.
+func pointer(tokens ...any) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		switch v := t.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		case string:
+			b.WriteString(pointerEscaper.Replace(v))
 		}
-		return opts[i].ID < opts[j].ID
-	})
-
-	h := sha1.New()
-	h.Write([]byte(s.Module))
-	h.Write([]byte("|"))
-	h.Write([]byte(s.Layout))
-	h.Write([]byte("|"))
-	h.Write([]byte(s.Finish))
-	h.Write([]byte("|"))
-	h.Write([]byte(strconv.Itoa(s.Dimensions.LengthMM)))
-	h.Write([]byte("x"))
-	h.Write([]byte(strconv.Itoa(s.Dimensions.HeightMM)))
-	h.Write([]byte("|"))
-	for _, opt := range opts {
-		h.Write([]byte(opt.ID))
-		h.Write([]byte("="))
-		h.Write([]byte(strconv.Itoa(opt.Quantity)))
-		h.Write([]byte(";"))
 	}
-	return hex.EncodeToString(h.Sum(nil))
+	return b.String()
 }
 
-// Violation describes a single blocking or warning state.
-type Violation struct {
-	Code     string `json:"code"`
-	Severity string `json:"severity"`
-	Message  string `json:"message"`
-}
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
 
-// ValidationResult wraps rule evaluation output.
+// ValidationResult wraps rule evaluation output. Fixes is only filled when a
+// caller asks for repairs and is never cached.
 type ValidationResult struct {
 	ConfigurationID string      `json:"configurationId"`
+	Fingerprint     string      `json:"fingerprint"`
 	Violations      []Violation `json:"violations"`
 	Blocking        bool        `json:"blocking"`
+	Packs           []string    `json:"packs"`
+	Locale          string      `json:"locale,omitempty"`
+	Policy          string      `json:"policy,omitempty"`
+	Trace           []RuleTrace `json:"trace,omitempty"`
+	Fixes           []Fix       `json:"fixes,omitempty"`
 	LatencyMicros   int64       `json:"latencyMicros"`
 	Cached          bool        `json:"cached"`
 }
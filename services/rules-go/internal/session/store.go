@@ -0,0 +1,151 @@
+// Package session keeps incremental validation state in the shared cache so
+// config panels can send deltas instead of resubmitting the full selection.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+var (
+	// ErrNotFound is returned for unknown or expired sessions.
+	ErrNotFound = errors.New("session not found")
+	// ErrConflict is returned when an update names a stale revision.
+	ErrConflict = errors.New("session revision conflict")
+	// ErrInvalid wraps rejected selections and changes.
+	ErrInvalid = errors.New("invalid session update")
+)
+
+const keyPrefix = "rules:session:"
+
+// Session is one client's evolving selection and its per-rule outcome.
+// Revision increments on every update so concurrent writers can detect each
+// other.
+type Session struct {
+	ID       string         `json:"id"`
+	Revision int            `json:"revision"`
+	Snapshot rules.Snapshot `json:"snapshot"`
+}
+
+// Store persists sessions in a cache. Every write renews the TTL, so a
+// session expires after ttl without activity.
+type Store struct {
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewStore returns a store writing sessions to c with the given idle TTL.
+func NewStore(c cache.Cache, ttl time.Duration) *Store {
+	return &Store{cache: c, ttl: ttl}
+}
+
+// Open validates the base selection in full and starts a session for it.
+func (s *Store) Open(ctx context.Context, engine *rules.Engine, sel rules.Selection) (Session, error) {
+	snap, err := engine.Snapshot(ctx, sel)
+	if err != nil {
+		return Session{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	sess := Session{ID: uuid.NewString(), Revision: 1, Snapshot: snap}
+	return sess, s.save(ctx, sess)
+}
+
+// Get loads a session. A session validated under an older rule set is
+// re-evaluated against the engine's version before it is returned.
+func (s *Store) Get(ctx context.Context, engine *rules.Engine, id string) (Session, error) {
+	sess, raw, err := s.load(ctx, id)
+	if err != nil {
+		return Session{}, err
+	}
+	if sess.Snapshot.Version == engine.Version() {
+		return sess, nil
+	}
+	snap, err := engine.Snapshot(ctx, sess.Snapshot.Selection)
+	if err != nil {
+		return Session{}, err
+	}
+	sess.Snapshot = snap
+	err = s.swap(ctx, raw, sess)
+	if errors.Is(err, ErrConflict) {
+		// Another writer replaced the session meanwhile; its copy wins.
+		return s.Get(ctx, engine, id)
+	}
+	return sess, err
+}
+
+// Apply runs changes against the session and stores the result. A revision
+// of zero skips the staleness check, but the write itself is a
+// compare-and-swap: of two concurrent updates, one fails with ErrConflict.
+func (s *Store) Apply(ctx context.Context, engine *rules.Engine, id string, revision int, changes []rules.Change) (Session, rules.Delta, error) {
+	if len(changes) == 0 {
+		return Session{}, rules.Delta{}, fmt.Errorf("%w: at least one change is required", ErrInvalid)
+	}
+	sess, raw, err := s.load(ctx, id)
+	if err != nil {
+		return Session{}, rules.Delta{}, err
+	}
+	if revision != 0 && revision != sess.Revision {
+		return Session{}, rules.Delta{}, fmt.Errorf("%w: at revision %d", ErrConflict, sess.Revision)
+	}
+	snap, delta, err := engine.Update(ctx, sess.Snapshot, changes)
+	if err != nil {
+		return Session{}, rules.Delta{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	sess.Snapshot = snap
+	sess.Revision++
+	if err := s.swap(ctx, raw, sess); err != nil {
+		return Session{}, rules.Delta{}, err
+	}
+	return sess, delta, nil
+}
+
+// load returns the session and the raw payload it was decoded from, which
+// swap compares against.
+func (s *Store) load(ctx context.Context, id string) (Session, string, error) {
+	raw, err := s.cache.Get(ctx, keyPrefix+id)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return Session{}, "", ErrNotFound
+	}
+	if err != nil {
+		return Session{}, "", err
+	}
+	var sess Session
+	if err := json.Unmarshal([]byte(raw), &sess); err != nil {
+		return Session{}, "", fmt.Errorf("decode session %s: %w", id, err)
+	}
+	return sess, raw, nil
+}
+
+// swap stores sess only if the cache still holds previous.
+func (s *Store) swap(ctx context.Context, previous string, sess Session) error {
+	payload, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	err = s.cache.Commit(ctx, cache.Write{
+		Key:         keyPrefix + sess.ID,
+		Value:       string(payload),
+		TTL:         s.ttl,
+		IfUnchanged: true,
+		Previous:    previous,
+	})
+	if errors.Is(err, cache.ErrConflict) {
+		return fmt.Errorf("%w: updated concurrently", ErrConflict)
+	}
+	return err
+}
+
+func (s *Store) save(ctx context.Context, sess Session) error {
+	payload, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, keyPrefix+sess.ID, string(payload), s.ttl)
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func TestSessionAppliesDeltasAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemoryCache()
+	engine := rules.NewEngine(nil, 0)
+	primary, replica := NewStore(shared, time.Minute), NewStore(shared, time.Minute)
+
+	sess, err := primary.Open(ctx, engine, rules.Selection{Module: "galley", Layout: "linear", Finish: "matte"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if engine.Result(sess.Snapshot).Blocking {
+		t.Fatalf("base selection should be valid")
+	}
+
+	next, delta, err := replica.Apply(ctx, engine, sess.ID, sess.Revision, []rules.Change{{Op: rules.OpAddOption, Option: "island-counter"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Revision != 2 || len(delta.Added) != 1 || !delta.Blocking {
+		t.Fatalf("unexpected update revision=%d delta=%+v", next.Revision, delta)
+	}
+
+	if _, _, err := primary.Apply(ctx, engine, sess.ID, sess.Revision, []rules.Change{{Op: rules.OpSetLayout, Layout: "island"}}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("stale revision should conflict, got %v", err)
+	}
+	_, delta, err = primary.Apply(ctx, engine, sess.ID, next.Revision, []rules.Change{{Op: rules.OpSetLayout, Layout: "island"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(delta.Removed) != 1 || delta.Blocking {
+		t.Fatalf("switching to island should clear the violation, got %+v", delta)
+	}
+
+	loaded, err := replica.Get(ctx, engine, sess.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Revision != 3 || loaded.Snapshot.Selection.Layout != "island" {
+		t.Fatalf("replica should see the latest state, got %+v", loaded)
+	}
+}
+
+func TestConcurrentAppliesOfOneRevisionConflict(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemoryCache()
+	engine := rules.NewEngine(nil, 0)
+	sess, err := NewStore(shared, time.Minute).Open(ctx, engine, rules.Selection{Module: "galley", Layout: "linear", Finish: "matte"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const replicas = 8
+	errs := make([]error, replicas)
+	var wg sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store := NewStore(shared, time.Minute)
+			_, _, errs[i] = store.Apply(ctx, engine, sess.ID, sess.Revision, []rules.Change{{Op: rules.OpAddOption, Option: "drawer-organizer"}})
+		}(i)
+	}
+	wg.Wait()
+
+	applied := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			applied++
+		case !errors.Is(err, ErrConflict):
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if applied != 1 {
+		t.Fatalf("expected exactly one apply to win, got %d", applied)
+	}
+	got, err := NewStore(shared, time.Minute).Get(ctx, engine, sess.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Revision != 2 || len(got.Snapshot.Selection.Options) != 1 {
+		t.Fatalf("expected one change at revision 2, got revision %d with %d options", got.Revision, len(got.Snapshot.Selection.Options))
+	}
+}
+
+func TestSessionExpires(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(cache.NewMemoryCache(), time.Millisecond)
+	engine := rules.NewEngine(nil, 0)
+	sess, err := store.Open(ctx, engine, rules.Selection{Module: "galley", Layout: "linear"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := store.Get(ctx, engine, sess.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected expired session, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Package simulate records a sample of live selections and replays them
+// against candidate rule sets to measure the impact of a rule change.
+package simulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+// corpusKey holds the corpus as a list with one JSON selection per entry.
+const corpusKey = "rules:corpus:samples"
+
+// Recorder samples validated selections into a shared corpus. Samples are
+// buffered in memory and appended to the store on Flush; the corpus keeps the
+// most recent limit selections. Each flush appends and trims in one batch, so
+// replicas flushing at once never overwrite each other's samples.
+type Recorder struct {
+	log   zerolog.Logger
+	store cache.Cache
+	rate  float64
+	limit int
+
+	mu      sync.Mutex
+	pending []rules.Selection
+}
+
+// NewRecorder returns a recorder keeping roughly rate of all selections, up
+// to limit in total. A zero rate or limit disables recording.
+func NewRecorder(log zerolog.Logger, store cache.Cache, rate float64, limit int) *Recorder {
+	return &Recorder{log: log, store: store, rate: rate, limit: limit}
+}
+
+// Record samples the selection. It is safe to call on a nil recorder.
+func (r *Recorder) Record(sel rules.Selection) {
+	if r == nil || r.rate <= 0 || r.limit <= 0 || rand.Float64() >= r.rate {
+		return
+	}
+	sel = Anonymise(sel)
+	r.mu.Lock()
+	r.pending = append(r.pending, sel)
+	if len(r.pending) > r.limit {
+		r.pending = r.pending[len(r.pending)-r.limit:]
+	}
+	r.mu.Unlock()
+}
+
+// Anonymise strips everything that could tie a stored sample back to its
+// caller: the configuration ID, channel, locale and signed acknowledgement
+// tokens. Replays then evaluate samples without a channel policy.
+func Anonymise(sel rules.Selection) rules.Selection {
+	sel.ConfigurationID = ""
+	sel.Channel = ""
+	sel.Locale = ""
+	sel.Acknowledgements = nil
+	return sel
+}
+
+// Flush appends buffered samples to the stored corpus.
+func (r *Recorder) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	writes := make([]cache.Write, 0, len(pending))
+	for _, sel := range pending {
+		payload, err := json.Marshal(sel)
+		if err != nil {
+			return err
+		}
+		writes = append(writes, cache.Write{Key: corpusKey, Value: string(payload), Append: true, Keep: r.limit})
+	}
+	return r.store.Commit(ctx, writes...)
+}
+
+// Corpus flushes pending samples and returns the stored corpus.
+func (r *Recorder) Corpus(ctx context.Context) ([]rules.Selection, error) {
+	if r == nil {
+		return make([]rules.Selection, 0), nil
+	}
+	if err := r.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return LoadCorpus(ctx, r.store)
+}
+
+// Run flushes periodically until ctx is cancelled, then flushes once more.
+func (r *Recorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := r.Flush(flushCtx); err != nil {
+				r.log.Warn().Err(err).Msg("selection sample flush failed")
+			}
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.log.Warn().Err(err).Msg("selection sample flush failed")
+			}
+		}
+	}
+}
+
+// LoadCorpus reads the stored corpus, oldest first; a missing corpus is
+// empty.
+func LoadCorpus(ctx context.Context, store cache.Cache) ([]rules.Selection, error) {
+	raw, err := store.List(ctx, corpusKey)
+	if err != nil {
+		return nil, err
+	}
+	corpus := make([]rules.Selection, 0, len(raw))
+	for _, entry := range raw {
+		var sel rules.Selection
+		if err := json.Unmarshal([]byte(entry), &sel); err != nil {
+			return nil, fmt.Errorf("decode corpus sample: %w", err)
+		}
+		corpus = append(corpus, sel)
+	}
+	return corpus, nil
+}
@@ -0,0 +1,174 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+// maxExamples caps the configuration IDs listed per change.
+const maxExamples = 5
+
+// Report summarizes how a candidate rule set changes outcomes for a corpus.
+type Report struct {
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	Selections   int          `json:"selections"`
+	Skipped      int          `json:"skipped"`
+	Changed      int          `json:"changed"`
+	NewlyBlocked Outcome      `json:"newlyBlocked"`
+	Unblocked    Outcome      `json:"unblocked"`
+	Codes        []CodeImpact `json:"codes"`
+}
+
+// Outcome counts selections whose blocking flag flipped.
+type Outcome struct {
+	Count    int      `json:"count"`
+	Examples []string `json:"examples"`
+}
+
+// CodeImpact counts selections where a violation code appears, disappears or
+// changes severity under the candidate set.
+type CodeImpact struct {
+	Code            string   `json:"code"`
+	Added           int      `json:"added"`
+	Removed         int      `json:"removed"`
+	SeverityChanged int      `json:"severityChanged"`
+	Examples        []string `json:"examples"`
+}
+
+// Replay validates every selection in the corpus against both rule sets.
+// Engines are built without a cache so replays never touch live results.
+// Selections the current engine rejects as malformed are skipped. Examples
+// name a selection by its configuration ID or, for anonymised samples, by
+// its corpus position ("#3"). The replay stops with the context's error once
+// the context is done.
+func Replay(ctx context.Context, corpus []rules.Selection, current, candidate rules.RuleSet) (Report, error) {
+	before, err := rules.NewEngineWithRules(current, nil, 0)
+	if err != nil {
+		return Report{}, err
+	}
+	after, err := rules.NewEngineWithRules(candidate, nil, 0)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		From:         current.Version,
+		To:           candidate.Version,
+		Selections:   len(corpus),
+		NewlyBlocked: Outcome{Examples: make([]string, 0)},
+		Unblocked:    Outcome{Examples: make([]string, 0)},
+		Codes:        make([]CodeImpact, 0),
+	}
+	impacts := make(map[string]*CodeImpact)
+	impact := func(code string) *CodeImpact {
+		if c, ok := impacts[code]; ok {
+			return c
+		}
+		c := &CodeImpact{Code: code, Examples: make([]string, 0)}
+		impacts[code] = c
+		return c
+	}
+
+	for i, sel := range corpus {
+		if err := ctx.Err(); err != nil {
+			return Report{}, err
+		}
+		id := sel.ConfigurationID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i)
+		}
+		was, err := before.Validate(ctx, sel)
+		if err != nil {
+			report.Skipped++
+			continue
+		}
+		now, err := after.Validate(ctx, sel)
+		if err != nil {
+			report.Skipped++
+			continue
+		}
+
+		changed := false
+		switch {
+		case now.Blocking && !was.Blocking:
+			report.NewlyBlocked.add(id)
+			changed = true
+		case was.Blocking && !now.Blocking:
+			report.Unblocked.add(id)
+			changed = true
+		}
+
+		wasCodes, nowCodes := severities(was.Violations), severities(now.Violations)
+		for code, severity := range nowCodes {
+			prev, ok := wasCodes[code]
+			switch {
+			case !ok:
+				c := impact(code)
+				c.Added++
+				c.example(id)
+				changed = true
+			case prev != severity:
+				c := impact(code)
+				c.SeverityChanged++
+				c.example(id)
+				changed = true
+			}
+		}
+		for code := range wasCodes {
+			if _, ok := nowCodes[code]; !ok {
+				c := impact(code)
+				c.Removed++
+				c.example(id)
+				changed = true
+			}
+		}
+		if changed {
+			report.Changed++
+		}
+	}
+
+	for _, c := range impacts {
+		report.Codes = append(report.Codes, *c)
+	}
+	sort.Slice(report.Codes, func(i, j int) bool {
+		a, b := report.Codes[i], report.Codes[j]
+		if ta, tb := a.total(), b.total(); ta != tb {
+			return ta > tb
+		}
+		return a.Code < b.Code
+	})
+	return report, nil
+}
+
+// severities maps each violation code to the most severe level it carries.
+func severities(violations []rules.Violation) map[string]string {
+	rank := map[string]int{"info": 0, "warning": 1, "error": 2}
+	out := make(map[string]string, len(violations))
+	for _, v := range violations {
+		if prev, ok := out[v.Code]; !ok || rank[v.Severity] > rank[prev] {
+			out[v.Code] = v.Severity
+		}
+	}
+	return out
+}
+
+func (o *Outcome) add(id string) {
+	o.Count++
+	if len(o.Examples) < maxExamples && id != "" {
+		o.Examples = append(o.Examples, id)
+	}
+}
+
+func (c *CodeImpact) example(id string) {
+	if len(c.Examples) < maxExamples && id != "" {
+		c.Examples = append(c.Examples, id)
+	}
+}
+
+func (c CodeImpact) total() int {
+	return c.Added + c.Removed + c.SeverityChanged
+}
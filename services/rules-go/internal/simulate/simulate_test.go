@@ -0,0 +1,151 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/parvizcorp/kitchen-configurator/services/go-kit/pkg/cache"
+	"github.com/rs/zerolog"
+
+	"github.com/parvizcorp/kitchen-configurator/services/rules-go/internal/rules"
+)
+
+func TestRecorderAnonymisesAndCapsCorpus(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	rec := NewRecorder(zerolog.Nop(), store, 1, 2)
+
+	for _, length := range []int{3000, 4000, 5000} {
+		rec.Record(rules.Selection{
+			ConfigurationID:  "cfg",
+			Module:           "galley",
+			Layout:           "linear",
+			Dimensions:       rules.Dimensions{LengthMM: length},
+			Channel:          "showroom-designer",
+			Locale:           "de-DE",
+			Acknowledgements: []string{"token"},
+		})
+	}
+	corpus, err := rec.Corpus(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(corpus) != 2 || corpus[0].Dimensions.LengthMM != 4000 || corpus[1].Dimensions.LengthMM != 5000 {
+		t.Fatalf("expected the two most recent samples, got %+v", corpus)
+	}
+	if s := corpus[0]; s.ConfigurationID != "" || s.Channel != "" || s.Locale != "" || s.Acknowledgements != nil {
+		t.Fatalf("samples should be anonymised, got %+v", s)
+	}
+
+	other := NewRecorder(zerolog.Nop(), store, 1, 2)
+	other.Record(rules.Selection{Module: "galley", Layout: "linear", Dimensions: rules.Dimensions{LengthMM: 6000}})
+	corpus, _ = other.Corpus(ctx)
+	if len(corpus) != 2 || corpus[1].Dimensions.LengthMM != 6000 {
+		t.Fatalf("replicas should share one corpus, got %+v", corpus)
+	}
+}
+
+func TestConcurrentFlushesKeepEverySample(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache()
+	recorders := make([]*Recorder, 4)
+	for i := range recorders {
+		recorders[i] = NewRecorder(zerolog.Nop(), store, 1, 100)
+		for j := 0; j < 5; j++ {
+			recorders[i].Record(rules.Selection{Module: "galley", Layout: "linear"})
+		}
+	}
+	var wg sync.WaitGroup
+	for _, rec := range recorders {
+		wg.Add(1)
+		go func(rec *Recorder) {
+			defer wg.Done()
+			if err := rec.Flush(ctx); err != nil {
+				t.Errorf("flush: %v", err)
+			}
+		}(rec)
+	}
+	wg.Wait()
+	corpus, err := LoadCorpus(ctx, store)
+	if err != nil || len(corpus) != 20 {
+		t.Fatalf("expected all 20 samples, got %d (%v)", len(corpus), err)
+	}
+}
+
+func TestRecorderDisabled(t *testing.T) {
+	rec := NewRecorder(zerolog.Nop(), cache.NewMemoryCache(), 0, 10)
+	rec.Record(rules.Selection{ConfigurationID: "a", Module: "galley", Layout: "linear"})
+	corpus, err := rec.Corpus(context.Background())
+	if err != nil || len(corpus) != 0 {
+		t.Fatalf("a zero rate should record nothing, got %v %v", corpus, err)
+	}
+	var none *Recorder
+	none.Record(rules.Selection{})
+}
+
+func TestReplayReportsChangedOutcomes(t *testing.T) {
+	current := rules.DefaultRuleSet()
+	candidate := rules.DefaultRuleSet()
+	candidate.Version = "tighter-u-shape"
+	candidate.Rules = append([]rules.Rule(nil), candidate.Rules...)
+	candidate.Rules[3].MaxMM = 5000       // u-shape-length
+	candidate.Rules = candidate.Rules[1:] // drop island-counter-requires-island
+
+	corpus := []rules.Selection{
+		{ConfigurationID: "long-u", Module: "galley", Layout: "u-shape", Dimensions: rules.Dimensions{LengthMM: 6000}},
+		{ConfigurationID: "short-u", Module: "galley", Layout: "u-shape", Dimensions: rules.Dimensions{LengthMM: 4000}},
+		{ConfigurationID: "linear-island", Module: "galley", Layout: "linear", Options: []rules.SelectionOption{{ID: "island-counter", Quantity: 1}}},
+		{ConfigurationID: "broken"},
+	}
+	report, err := Replay(context.Background(), corpus, current, candidate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Selections != 4 || report.Skipped != 1 || report.Changed != 2 {
+		t.Fatalf("unexpected totals %+v", report)
+	}
+	if report.NewlyBlocked.Count != 1 || report.NewlyBlocked.Examples[0] != "long-u" {
+		t.Fatalf("expected long-u to be newly blocked, got %+v", report.NewlyBlocked)
+	}
+	if report.Unblocked.Count != 1 || report.Unblocked.Examples[0] != "linear-island" {
+		t.Fatalf("expected linear-island to be unblocked, got %+v", report.Unblocked)
+	}
+	codes := map[string]CodeImpact{}
+	for _, c := range report.Codes {
+		codes[c.Code] = c
+	}
+	if c := codes["dimension.u-shape"]; c.Added != 1 || c.Examples[0] != "long-u" {
+		t.Fatalf("unexpected dimension impact %+v", c)
+	}
+	if c := codes["layout.island-counter"]; c.Removed != 1 || c.Examples[0] != "linear-island" {
+		t.Fatalf("unexpected layout impact %+v", c)
+	}
+}
+
+func TestReplayStopsWhenTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	corpus := []rules.Selection{{Module: "galley", Layout: "linear"}}
+	if _, err := Replay(ctx, corpus, rules.DefaultRuleSet(), rules.DefaultRuleSet()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReplayNamesAnonymisedSamplesByPosition(t *testing.T) {
+	candidate := rules.DefaultRuleSet()
+	candidate.Version = "no-island-rule"
+	candidate.Rules = candidate.Rules[1:]
+	corpus := []rules.Selection{
+		{Module: "galley", Layout: "island", Dimensions: rules.Dimensions{LengthMM: 5000}},
+		Anonymise(rules.Selection{ConfigurationID: "cfg", Module: "galley", Layout: "linear", Options: []rules.SelectionOption{{ID: "island-counter", Quantity: 1}}}),
+	}
+	report, err := Replay(context.Background(), corpus, rules.DefaultRuleSet(), candidate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Unblocked.Count != 1 || report.Unblocked.Examples[0] != "#1" {
+		t.Fatalf("expected the second sample named by position, got %+v", report.Unblocked)
+	}
+}